@@ -2,6 +2,7 @@ package app
 
 import (
 	"HyPrism/internal/config"
+	"HyPrism/internal/instance"
 	"HyPrism/internal/pwr"
 )
 
@@ -73,20 +74,36 @@ func (a *App) SetSelectedVersion(version int) error {
 	return config.Save(a.cfg)
 }
 
-// VersionCheckInfo represents version availability information
+// VersionCheckInfo represents version availability information for an
+// instance, comparing its recorded installed version against the latest
+// published one for its branch.
 type VersionCheckInfo struct {
-	Available bool `json:"available"`
-	Version   int  `json:"version"`
-}
+	Available        bool `json:"available"`
+	Version          int  `json:"version"`
+	InstalledVersion int  `json:"installedVersion"`
+	UpdateAvailable  bool `json:"updateAvailable"`
+}
+
+// CheckVersionAvailability checks whether a newer version than the given
+// instance's recorded installed version is available for its branch.
+func (a *App) CheckVersionAvailability(instanceID string) (VersionCheckInfo, error) {
+	inst, err := instance.Get(instanceID)
+	if err != nil {
+		return VersionCheckInfo{}, err
+	}
 
-// CheckVersionAvailability checks if a version is available for the current platform
-func (a *App) CheckVersionAvailability() VersionCheckInfo {
-	versionType := a.GetVersionType()
-	result := pwr.FindLatestVersionWithDetails(versionType)
-	return VersionCheckInfo{
-		Available: result.LatestVersion > 0,
-		Version:   result.LatestVersion,
+	result := pwr.FindLatestVersionWithDetails(inst.VersionType)
+	info := VersionCheckInfo{
+		Available:        result.LatestVersion > 0,
+		Version:          result.LatestVersion,
+		InstalledVersion: inst.Version,
+	}
+	if rec, err := instance.ReadVersionRecord(inst.Path); err == nil {
+		info.InstalledVersion = rec.Version
 	}
+	info.UpdateAvailable = info.Available && info.Version > info.InstalledVersion
+
+	return info, nil
 }
 
 // GetCustomInstanceDir returns the custom instance directory path
@@ -110,3 +127,47 @@ func (a *App) SetAutoUpdateLatest(enabled bool) error {
 	a.cfg.AutoUpdateLatest = enabled
 	return config.Save(a.cfg)
 }
+
+// GetHealthcheckServerEnabled returns whether the opt-in healthcheck HTTP
+// server (/livez, /readyz, /healthz, /metrics) should run, for headless
+// dedicated-server hosts that want to script or monitor this instance.
+func (a *App) GetHealthcheckServerEnabled() bool {
+	return a.cfg.HealthcheckServerEnabled
+}
+
+// SetHealthcheckServerEnabled sets whether the healthcheck HTTP server
+// should run and starts or stops it immediately to match.
+func (a *App) SetHealthcheckServerEnabled(enabled bool) error {
+	a.cfg.HealthcheckServerEnabled = enabled
+	if err := config.Save(a.cfg); err != nil {
+		return err
+	}
+
+	if enabled {
+		return a.StartHealthcheckServer(a.GetHealthcheckServerAddr())
+	}
+	return a.StopHealthcheckServer()
+}
+
+// GetHealthcheckServerAddr returns the listen address for the healthcheck
+// HTTP server, defaulting to localhost-only.
+func (a *App) GetHealthcheckServerAddr() string {
+	if a.cfg.HealthcheckServerAddr == "" {
+		return "127.0.0.1:9090"
+	}
+	return a.cfg.HealthcheckServerAddr
+}
+
+// SetHealthcheckServerAddr sets the listen address for the healthcheck
+// HTTP server, applying it immediately if the server is currently enabled.
+func (a *App) SetHealthcheckServerAddr(addr string) error {
+	a.cfg.HealthcheckServerAddr = addr
+	if err := config.Save(a.cfg); err != nil {
+		return err
+	}
+
+	if a.cfg.HealthcheckServerEnabled {
+		return a.StartHealthcheckServer(addr)
+	}
+	return nil
+}
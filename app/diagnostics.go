@@ -1,25 +1,34 @@
 package app
 
 import (
+	"HyPrism/internal/crashreport"
 	"HyPrism/internal/env"
+	"HyPrism/internal/game"
+	"HyPrism/internal/healthcheck"
+	"HyPrism/internal/instance"
 	"HyPrism/internal/java"
 	"HyPrism/internal/pwr/butler"
+	"archive/zip"
+	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"sync"
 	"time"
 )
 
 // DiagnosticReport contains system diagnostic information
 type DiagnosticReport struct {
-	Platform      PlatformInfo      `json:"platform"`
-	Connectivity  ConnectivityInfo  `json:"connectivity"`
-	GameStatus    GameStatusInfo    `json:"gameStatus"`
-	Dependencies  DependenciesInfo  `json:"dependencies"`
-	Timestamp     string            `json:"timestamp"`
+	Platform     PlatformInfo     `json:"platform"`
+	Connectivity ConnectivityInfo `json:"connectivity"`
+	GameStatus   []InstanceStatus `json:"gameStatus"`
+	Dependencies DependenciesInfo `json:"dependencies"`
+	Timestamp    string           `json:"timestamp"`
 }
 
 type PlatformInfo struct {
@@ -35,11 +44,16 @@ type ConnectivityInfo struct {
 	Error         string `json:"error,omitempty"`
 }
 
-type GameStatusInfo struct {
-	Installed       bool   `json:"installed"`
-	Version         string `json:"version"`
-	ClientExists    bool   `json:"clientExists"`
+// InstanceStatus is one instance's install/version/fix state, as reported
+// by checkGameStatus for every entry in the instance manifest.
+type InstanceStatus struct {
+	InstanceID       string `json:"instanceId"`
+	Name             string `json:"name"`
+	Installed        bool   `json:"installed"`
+	Version          string `json:"version"`
+	ClientExists     bool   `json:"clientExists"`
 	OnlineFixApplied bool   `json:"onlineFixApplied"`
+	Error            string `json:"error,omitempty"`
 }
 
 type DependenciesInfo struct {
@@ -49,6 +63,97 @@ type DependenciesInfo struct {
 	ButlerPath      string `json:"butlerPath"`
 }
 
+// connectivityCheck, gameStatusCheck, and dependenciesCheck adapt the
+// existing check functions to healthcheck.Healthcheck so they can run
+// standalone (e.g. on an opt-in HTTP scrape) as well as bundled together
+// in RunDiagnostics.
+type connectivityCheck struct{}
+
+func (connectivityCheck) Name() string     { return "connectivity" }
+func (connectivityCheck) Critical() bool   { return false }
+func (connectivityCheck) Check(ctx context.Context) healthcheck.Result {
+	info := checkConnectivity()
+	return healthcheck.Result{OK: info.Error == "", Detail: info}
+}
+
+type gameStatusCheck struct{}
+
+func (gameStatusCheck) Name() string   { return "gameStatus" }
+func (gameStatusCheck) Critical() bool { return false }
+func (gameStatusCheck) Check(ctx context.Context) healthcheck.Result {
+	statuses := checkGameStatus()
+	ok := len(statuses) == 0
+	for _, s := range statuses {
+		if s.Installed {
+			ok = true
+			break
+		}
+	}
+	return healthcheck.Result{OK: ok, Detail: statuses}
+}
+
+type dependenciesCheck struct{}
+
+func (dependenciesCheck) Name() string   { return "dependencies" }
+func (dependenciesCheck) Critical() bool { return true }
+func (dependenciesCheck) Check(ctx context.Context) healthcheck.Result {
+	info := checkDependencies()
+	return healthcheck.Result{OK: info.JavaInstalled && info.ButlerInstalled, Detail: info}
+}
+
+var (
+	healthRegistryOnce sync.Once
+	healthRegistry     *healthcheck.Registry
+	healthServerMu     sync.Mutex
+	healthServer       *healthcheck.Server
+)
+
+// Registry returns the process-wide healthcheck registry, building it on
+// first use. New checks (Java version parsing, disk space, Butler
+// self-update status, corrupt-instance detection, ...) register here
+// instead of growing RunDiagnostics.
+func Registry() *healthcheck.Registry {
+	healthRegistryOnce.Do(func() {
+		healthRegistry = healthcheck.NewRegistry()
+		healthRegistry.Register(connectivityCheck{})
+		healthRegistry.Register(gameStatusCheck{})
+		healthRegistry.Register(dependenciesCheck{})
+	})
+	return healthRegistry
+}
+
+// StartHealthcheckServer starts the opt-in HTTP server exposing /livez,
+// /readyz, /healthz, and /metrics for the registry above. Calling it
+// again while already running restarts it on the new address.
+func (a *App) StartHealthcheckServer(addr string) error {
+	healthServerMu.Lock()
+	defer healthServerMu.Unlock()
+
+	if healthServer != nil {
+		_ = healthServer.Stop(context.Background())
+	}
+
+	healthServer = healthcheck.NewServer(Registry(), addr)
+	if err := healthServer.Start(); err != nil {
+		healthServer = nil
+		return err
+	}
+	return nil
+}
+
+// StopHealthcheckServer stops the healthcheck HTTP server if running.
+func (a *App) StopHealthcheckServer() error {
+	healthServerMu.Lock()
+	defer healthServerMu.Unlock()
+
+	if healthServer == nil {
+		return nil
+	}
+	err := healthServer.Stop(context.Background())
+	healthServer = nil
+	return err
+}
+
 // RunDiagnostics runs system diagnostics
 func (a *App) RunDiagnostics() DiagnosticReport {
 	report := DiagnosticReport{
@@ -62,14 +167,17 @@ func (a *App) RunDiagnostics() DiagnosticReport {
 		Version: AppVersion,
 	}
 
-	// Connectivity checks
-	report.Connectivity = checkConnectivity()
-
-	// Game status
-	report.GameStatus = checkGameStatus()
-
-	// Dependencies
-	report.Dependencies = checkDependencies()
+	results := Registry().RunAll(context.Background())
+	for _, res := range results {
+		switch detail := res.Detail.(type) {
+		case ConnectivityInfo:
+			report.Connectivity = detail
+		case []InstanceStatus:
+			report.GameStatus = detail
+		case DependenciesInfo:
+			report.Dependencies = detail
+		}
+	}
 
 	return report
 }
@@ -107,40 +215,51 @@ func checkConnectivity() ConnectivityInfo {
 	return info
 }
 
-func checkGameStatus() GameStatusInfo {
-	info := GameStatusInfo{}
-
-	// Check release-latest instance (version 0)
-	gameDir := env.GetInstanceGameDir("release", 0)
+// checkGameStatus reports per-instance install state for every entry in
+// the instance manifest, rather than assuming a single release-latest
+// install the way this used to.
+func checkGameStatus() []InstanceStatus {
+	instances, err := instance.List()
+	if err != nil {
+		return []InstanceStatus{{Error: err.Error()}}
+	}
 
-	// Check if game is installed
 	clientName := "HytaleClient"
 	if runtime.GOOS == "windows" {
 		clientName += ".exe"
 	}
 
-	clientPath := filepath.Join(gameDir, "Client", clientName)
-	if _, err := os.Stat(clientPath); err == nil {
-		info.Installed = true
-		info.ClientExists = true
-	}
+	statuses := make([]InstanceStatus, 0, len(instances))
+	for _, inst := range instances {
+		status := InstanceStatus{
+			InstanceID: inst.ID,
+			Name:       inst.Name,
+			Version:    fmt.Sprintf("%s-%d", inst.VersionType, inst.Version),
+		}
 
-	// Check if any release instance is installed
-	if env.IsVersionInstalled("release", 0) {
-		info.Version = "release-latest"
-	}
+		clientPath := filepath.Join(inst.Path, "Client", clientName)
+		if _, err := os.Stat(clientPath); err == nil {
+			status.Installed = true
+			status.ClientExists = true
+		}
 
-	// Check if online fix is applied (Windows only)
-	if runtime.GOOS == "windows" {
-		serverBat := filepath.Join(gameDir, "Server", "start-server.bat")
-		if _, err := os.Stat(serverBat); err == nil {
-			info.OnlineFixApplied = true
+		if runtime.GOOS == "windows" {
+			serverBat := filepath.Join(inst.Path, "Server", "start-server.bat")
+			if _, err := os.Stat(serverBat); err == nil {
+				status.OnlineFixApplied = true
+			}
+		} else {
+			status.OnlineFixApplied = true // Not needed on other platforms
 		}
-	} else {
-		info.OnlineFixApplied = true // Not needed on other platforms
+
+		if err := instance.Validate(inst.ID); err != nil {
+			status.Error = err.Error()
+		}
+
+		statuses = append(statuses, status)
 	}
 
-	return info
+	return statuses
 }
 
 func checkDependencies() DependenciesInfo {
@@ -165,19 +284,23 @@ func checkDependencies() DependenciesInfo {
 	return info
 }
 
-// SaveDiagnosticReport saves diagnostics to a file
-func (a *App) SaveDiagnosticReport() (string, error) {
-	report := a.RunDiagnostics()
-	
-	logsDir := filepath.Join(env.GetDefaultAppDir(), "logs")
-	if err := os.MkdirAll(logsDir, 0755); err != nil {
-		return "", err
+// buildDiagnosticText renders a DiagnosticReport as the plaintext report,
+// with one line per instance under GAME STATUS.
+func buildDiagnosticText(report DiagnosticReport) string {
+	var gameStatus strings.Builder
+	if len(report.GameStatus) == 0 {
+		gameStatus.WriteString("(no instances configured)\n")
+	}
+	for _, s := range report.GameStatus {
+		gameStatus.WriteString(fmt.Sprintf("- %s [%s]: installed=%v clientExists=%v onlineFixApplied=%v",
+			s.Name, s.Version, s.Installed, s.ClientExists, s.OnlineFixApplied))
+		if s.Error != "" {
+			gameStatus.WriteString(fmt.Sprintf(" error=%s", s.Error))
+		}
+		gameStatus.WriteString("\n")
 	}
 
-	filename := fmt.Sprintf("diagnostic_%s.txt", time.Now().Format("2006-01-02_15-04-05"))
-	filepath := filepath.Join(logsDir, filename)
-
-	content := fmt.Sprintf(`HyPrism Diagnostic Report
+	return fmt.Sprintf(`HyPrism Diagnostic Report
 Generated: %s
 
 === PLATFORM ===
@@ -192,11 +315,7 @@ itch.io (Butler): %v
 Error: %s
 
 === GAME STATUS ===
-Installed: %v
-Version: %s
-Client Exists: %v
-Online Fix Applied: %v
-
+%s
 === DEPENDENCIES ===
 Java Installed: %v
 Java Path: %s
@@ -206,15 +325,53 @@ Butler Path: %s
 		report.Timestamp,
 		report.Platform.OS, report.Platform.Arch, report.Platform.Version,
 		report.Connectivity.HytalePatches, report.Connectivity.GitHub, report.Connectivity.ItchIO, report.Connectivity.Error,
-		report.GameStatus.Installed, report.GameStatus.Version, report.GameStatus.ClientExists, report.GameStatus.OnlineFixApplied,
+		gameStatus.String(),
 		report.Dependencies.JavaInstalled, report.Dependencies.JavaPath, report.Dependencies.ButlerInstalled, report.Dependencies.ButlerPath,
 	)
+}
+
+// SaveDiagnosticReport saves diagnostics to a file in the given format:
+// "txt" (default), "json", "md", or "zip". See diagnostics_export.go for
+// the non-plaintext formats. The redaction pass applies to the JSON output
+// (including the JSON copy inside the zip); the txt and md renderings, and
+// the zip's plaintext report and game log, are written unredacted.
+func (a *App) SaveDiagnosticReport(format string) (string, error) {
+	report := a.RunDiagnostics()
 
-	if err := os.WriteFile(filepath, []byte(content), 0644); err != nil {
+	logsDir := filepath.Join(env.GetDefaultAppDir(), "logs")
+	if err := os.MkdirAll(logsDir, 0755); err != nil {
 		return "", err
 	}
+	timestamp := time.Now().Format("2006-01-02_15-04-05")
 
-	return filepath, nil
+	switch format {
+	case "", "txt":
+		path := filepath.Join(logsDir, fmt.Sprintf("diagnostic_%s.txt", timestamp))
+		if err := os.WriteFile(path, []byte(buildDiagnosticText(report)), 0644); err != nil {
+			return "", err
+		}
+		return path, nil
+	case "json":
+		data, err := redactedDiagnosticJSON(report)
+		if err != nil {
+			return "", err
+		}
+		path := filepath.Join(logsDir, fmt.Sprintf("diagnostic_%s.json", timestamp))
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return "", err
+		}
+		return path, nil
+	case "md":
+		path := filepath.Join(logsDir, fmt.Sprintf("diagnostic_%s.md", timestamp))
+		if err := os.WriteFile(path, []byte(buildDiagnosticMarkdown(report)), 0644); err != nil {
+			return "", err
+		}
+		return path, nil
+	case "zip":
+		return a.saveDiagnosticReportZip(report, logsDir, timestamp)
+	default:
+		return "", fmt.Errorf("unknown diagnostic report format %q", format)
+	}
 }
 
 // CrashReport represents a crash report
@@ -222,13 +379,18 @@ type CrashReport struct {
 	Filename  string `json:"filename"`
 	Timestamp string `json:"timestamp"`
 	Preview   string `json:"preview"`
+	Signature string `json:"signature"`
+}
+
+func crashDir() string {
+	return filepath.Join(env.GetDefaultAppDir(), "crashes")
 }
 
 // GetCrashReports returns available crash reports
 func (a *App) GetCrashReports() ([]CrashReport, error) {
-	crashDir := filepath.Join(env.GetDefaultAppDir(), "crashes")
-	
-	entries, err := os.ReadDir(crashDir)
+	dir := crashDir()
+
+	entries, err := os.ReadDir(dir)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return []CrashReport{}, nil
@@ -252,14 +414,14 @@ func (a *App) GetCrashReports() ([]CrashReport, error) {
 			Timestamp: info.ModTime().Format(time.RFC3339),
 		}
 
-		// Read first 500 bytes as preview
-		content, err := os.ReadFile(filepath.Join(crashDir, entry.Name()))
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
 		if err == nil {
 			if len(content) > 500 {
 				report.Preview = string(content[:500]) + "..."
 			} else {
 				report.Preview = string(content)
 			}
+			report.Signature = crashreport.Parse(entry.Name(), content).Signature
 		}
 
 		reports = append(reports, report)
@@ -267,3 +429,111 @@ func (a *App) GetCrashReports() ([]CrashReport, error) {
 
 	return reports, nil
 }
+
+// ParseCrashReport reads and structurally parses one crash log, extracting
+// its exception/panic type, stack frames, loaded modules, and a stable
+// grouping signature.
+func (a *App) ParseCrashReport(filename string) (*crashreport.ParsedCrash, error) {
+	content, err := os.ReadFile(filepath.Join(crashDir(), filename))
+	if err != nil {
+		return nil, err
+	}
+	return crashreport.Parse(filename, content), nil
+}
+
+// GetCrashGroups returns every crash report parsed and collapsed by
+// signature, newest group first, so the UI can show occurrence counts
+// instead of one entry per repeated crash.
+func (a *App) GetCrashGroups() ([]crashreport.Group, error) {
+	dir := crashDir()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []crashreport.Group{}, nil
+		}
+		return nil, err
+	}
+
+	var crashes []crashreport.ParsedCrash
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		crashes = append(crashes, *crashreport.Parse(entry.Name(), content))
+	}
+
+	return crashreport.GroupBySignature(crashes), nil
+}
+
+// ExportCrashBundle zips a raw crash log, its parsed JSON, the most recent
+// diagnostic report, and the tail of the game log into a single artifact
+// suitable for attaching to a bug report. When redact is true, usernames
+// embedded in paths and common secret-shaped tokens are stripped first.
+func (a *App) ExportCrashBundle(filename string, redact bool) (string, error) {
+	rawPath := filepath.Join(crashDir(), filename)
+	raw, err := os.ReadFile(rawPath)
+	if err != nil {
+		return "", fmt.Errorf("reading crash report: %w", err)
+	}
+
+	parsed := crashreport.Parse(filename, raw)
+	parsedJSON, err := json.MarshalIndent(parsed, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshaling parsed crash: %w", err)
+	}
+
+	diagJSON, err := json.MarshalIndent(a.RunDiagnostics(), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshaling diagnostic report: %w", err)
+	}
+
+	gameLog, _ := game.GetGameLogs()
+
+	rawText := string(raw)
+	diagText := string(diagJSON)
+	logText := gameLog
+	if redact {
+		rawText = crashreport.Redact(rawText)
+		diagText = crashreport.Redact(diagText)
+		logText = crashreport.Redact(logText)
+	}
+
+	logsDir := filepath.Join(env.GetDefaultAppDir(), "logs")
+	if err := os.MkdirAll(logsDir, 0755); err != nil {
+		return "", err
+	}
+	bundlePath := filepath.Join(logsDir, fmt.Sprintf("crash-bundle_%s.zip", time.Now().Format("2006-01-02_15-04-05")))
+
+	out, err := os.Create(bundlePath)
+	if err != nil {
+		return "", err
+	}
+	defer out.Close()
+
+	zw := zip.NewWriter(out)
+	files := map[string]string{
+		filename:              rawText,
+		"parsed_crash.json":   string(parsedJSON),
+		"diagnostic_report.json": diagText,
+		"game_log.txt":        logText,
+	}
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			return "", err
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			return "", err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return "", err
+	}
+
+	return bundlePath, nil
+}
@@ -0,0 +1,242 @@
+package app
+
+import (
+	"HyPrism/internal/game"
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// buildDiagnosticMarkdown renders a DiagnosticReport as a Markdown document,
+// for pasting directly into a GitHub issue or Discord bug report.
+func buildDiagnosticMarkdown(report DiagnosticReport) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# HyPrism Diagnostic Report\n\n_Generated: %s_\n\n", report.Timestamp)
+
+	fmt.Fprintf(&sb, "## Platform\n\n- OS: `%s`\n- Arch: `%s`\n- Launcher version: `%s`\n\n",
+		report.Platform.OS, report.Platform.Arch, report.Platform.Version)
+
+	fmt.Fprintf(&sb, "## Connectivity\n\n- Hytale patches server: %s\n- GitHub API: %s\n- itch.io (Butler): %s\n",
+		checkmark(report.Connectivity.HytalePatches), checkmark(report.Connectivity.GitHub), checkmark(report.Connectivity.ItchIO))
+	if report.Connectivity.Error != "" {
+		fmt.Fprintf(&sb, "- Error: `%s`\n", report.Connectivity.Error)
+	}
+	sb.WriteString("\n## Game Status\n\n")
+	if len(report.GameStatus) == 0 {
+		sb.WriteString("_(no instances configured)_\n\n")
+	} else {
+		sb.WriteString("| Instance | Version | Installed | Client | Online fix | Error |\n")
+		sb.WriteString("|---|---|---|---|---|---|\n")
+		for _, s := range report.GameStatus {
+			fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s | %s |\n",
+				s.Name, s.Version, checkmark(s.Installed), checkmark(s.ClientExists), checkmark(s.OnlineFixApplied), s.Error)
+		}
+		sb.WriteString("\n")
+	}
+
+	fmt.Fprintf(&sb, "## Dependencies\n\n- Java: %s (`%s`)\n- Butler: %s (`%s`)\n",
+		checkmark(report.Dependencies.JavaInstalled), report.Dependencies.JavaPath,
+		checkmark(report.Dependencies.ButlerInstalled), report.Dependencies.ButlerPath)
+
+	return sb.String()
+}
+
+func checkmark(ok bool) string {
+	if ok {
+		return "✅"
+	}
+	return "❌"
+}
+
+// homeDirPathPattern matches an absolute path under the user's home
+// directory, so redactedDiagnosticJSON can rewrite it to "$HOME/...".
+func homeDirPathPattern() *regexp.Regexp {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return nil
+	}
+	return regexp.MustCompile(regexp.QuoteMeta(home))
+}
+
+// dnsResolverPattern strips resolver addresses (e.g. "192.168.1.1:53")
+// that sometimes leak into net.LookupHost error strings.
+var dnsResolverPattern = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}:\d+\b`)
+
+// redactString applies every redaction rule to a single string value.
+func redactString(s string, homePattern *regexp.Regexp, butlerKey string) string {
+	if homePattern != nil {
+		s = homePattern.ReplaceAllLiteralString(s, "$HOME")
+	}
+	s = dnsResolverPattern.ReplaceAllString(s, "<redacted-dns>")
+	if butlerKey != "" {
+		s = strings.ReplaceAll(s, butlerKey, "<redacted-butler-key>")
+	}
+	return s
+}
+
+// redactJSONTree walks a generic decoded-JSON value (map/slice/string/...)
+// and redacts every string leaf, so new DiagnosticReport fields are
+// covered automatically instead of needing their own redaction case.
+func redactJSONTree(v any, homePattern *regexp.Regexp, butlerKey string) any {
+	switch val := v.(type) {
+	case string:
+		return redactString(val, homePattern, butlerKey)
+	case map[string]any:
+		for k, child := range val {
+			val[k] = redactJSONTree(child, homePattern, butlerKey)
+		}
+		return val
+	case []any:
+		for i, child := range val {
+			val[i] = redactJSONTree(child, homePattern, butlerKey)
+		}
+		return val
+	default:
+		return val
+	}
+}
+
+// redactedDiagnosticJSON marshals report, then walks the resulting JSON
+// tree rewriting absolute paths under the user's home directory to
+// "$HOME/...", stripping DNS resolver addresses out of connectivity
+// errors, and masking the Butler API key (read from the environment) if
+// it appears anywhere - so the file is safe to paste publicly.
+func redactedDiagnosticJSON(report DiagnosticReport) ([]byte, error) {
+	raw, err := json.Marshal(report)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling diagnostic report: %w", err)
+	}
+
+	var tree any
+	if err := json.Unmarshal(raw, &tree); err != nil {
+		return nil, fmt.Errorf("decoding diagnostic report for redaction: %w", err)
+	}
+
+	tree = redactJSONTree(tree, homeDirPathPattern(), os.Getenv("BUTLER_API_KEY"))
+
+	return json.MarshalIndent(tree, "", "  ")
+}
+
+// crashPreviewCount is how many recent crash previews ride along in a zip
+// export - enough to spot a recurring crash without ballooning the bundle.
+const crashPreviewCount = 5
+
+// saveDiagnosticReportZip bundles the plaintext report, the redacted JSON
+// report, a tail of the launcher/game log, and the most recent crash
+// previews into a single zip suitable for attaching to a bug report.
+func (a *App) saveDiagnosticReportZip(report DiagnosticReport, logsDir, timestamp string) (string, error) {
+	jsonData, err := redactedDiagnosticJSON(report)
+	if err != nil {
+		return "", err
+	}
+
+	gameLog, _ := game.GetGameLogs()
+
+	crashes, _ := a.GetCrashReports()
+	sort.Slice(crashes, func(i, j int) bool {
+		return crashes[i].Timestamp > crashes[j].Timestamp
+	})
+	if len(crashes) > crashPreviewCount {
+		crashes = crashes[:crashPreviewCount]
+	}
+	var crashPreviews strings.Builder
+	for _, c := range crashes {
+		fmt.Fprintf(&crashPreviews, "=== %s (%s) ===\n%s\n\n", c.Filename, c.Timestamp, c.Preview)
+	}
+
+	path := filepath.Join(logsDir, fmt.Sprintf("diagnostic_%s.zip", timestamp))
+	out, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer out.Close()
+
+	zw := zip.NewWriter(out)
+	files := map[string]string{
+		"diagnostic_report.txt":  buildDiagnosticText(report),
+		"diagnostic_report.json": string(jsonData),
+		"game_log.txt":           gameLog,
+		"recent_crashes.txt":     crashPreviews.String(),
+	}
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			return "", err
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			return "", err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// UploadDiagnosticReport builds a zip diagnostic bundle and POSTs it to a
+// user-configured endpoint (a pastebin, the maintainers' support intake,
+// ...), returning the URL the endpoint reports back so the user can share
+// it in a bug report.
+func (a *App) UploadDiagnosticReport(url string, headers map[string]string) (string, error) {
+	zipPath, err := a.SaveDiagnosticReport("zip")
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(zipPath)
+	if err != nil {
+		return "", fmt.Errorf("reading diagnostic bundle: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("building upload request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/zip")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("uploading diagnostic report: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("reading upload response: %w", err)
+	}
+
+	if resp.StatusCode >= 300 {
+		return "", fmt.Errorf("upload endpoint returned HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return extractUploadURL(body), nil
+}
+
+// extractUploadURL pulls a result URL out of the upload endpoint's
+// response, trying common JSON field names before falling back to
+// treating the whole body as the URL (for pastebin-style plaintext APIs).
+func extractUploadURL(body []byte) string {
+	var parsed map[string]any
+	if err := json.Unmarshal(body, &parsed); err == nil {
+		for _, key := range []string{"url", "link", "href"} {
+			if v, ok := parsed[key].(string); ok && v != "" {
+				return v
+			}
+		}
+	}
+	return strings.TrimSpace(string(body))
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+
+	"HyPrism/internal/game"
+	"HyPrism/internal/instance"
+)
+
+// ListInstances returns every configured game instance.
+func (a *App) ListInstances() ([]instance.Instance, error) {
+	return instance.List()
+}
+
+// CreateInstance registers a new named instance. path may be empty to use
+// the default per-instance directory (optionally overridden globally via
+// CustomInstanceDir).
+func (a *App) CreateInstance(name, versionType string, version int, path string) (*instance.Instance, error) {
+	return instance.Create(name, versionType, version, path)
+}
+
+// DeleteInstance removes an instance from the manifest and deletes its files.
+func (a *App) DeleteInstance(id string) error {
+	return instance.Delete(id, true)
+}
+
+// RenameInstance changes an instance's display name.
+func (a *App) RenameInstance(id, name string) error {
+	return instance.Rename(id, name)
+}
+
+// SelectInstance marks an instance as the active one for launch/play.
+func (a *App) SelectInstance(id string) error {
+	return instance.Select(id)
+}
+
+// GetSelectedInstance returns the currently selected instance, if any.
+func (a *App) GetSelectedInstance() (*instance.Instance, error) {
+	return instance.GetSelected()
+}
+
+// DuplicateInstance copies an existing instance's files into a new named
+// instance with the same version/profile settings.
+func (a *App) DuplicateInstance(id, newName string) (*instance.Instance, error) {
+	return instance.Duplicate(id, newName)
+}
+
+// VerifyInstallation re-hashes an instance's client executable against the
+// hash recorded at install time and reports whether it still matches.
+func (a *App) VerifyInstallation(instanceID string) (instance.VerifyReport, error) {
+	return instance.Verify(instanceID)
+}
+
+// InstallInstance downloads and installs the named instance's game files,
+// the UI entry point for a newly created (or repaired) instance that has no
+// files on disk yet.
+func (a *App) InstallInstance(instanceID string) error {
+	return game.InstallInstance(context.Background(), instanceID, nil)
+}
@@ -0,0 +1,127 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"HyPrism/internal/env"
+	"HyPrism/internal/instance"
+	"HyPrism/internal/java"
+	"HyPrism/internal/launch"
+)
+
+// BuildLaunchCommand resolves an instance's launch.json (or a synthesized
+// default, for instances installed before launch.json existed) into the
+// final java args, game args and classpath, without starting the process.
+// This lets the UI preview/toggle features like windowed mode before play.
+func (a *App) BuildLaunchCommand(instanceID string, features map[string]bool) (*launch.Resolved, error) {
+	inst, err := instance.Get(instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := launch.LoadManifest(filepath.Join(inst.Path, "launch.json"))
+	if err != nil {
+		return nil, fmt.Errorf("loading launch manifest for %q: %w", inst.Name, err)
+	}
+
+	playerName := inst.PlayerName
+	if playerName == "" {
+		playerName = a.cfg.Nick
+	}
+
+	ctx := launch.Context{
+		PlayerName:    playerName,
+		GameDirectory: inst.Path,
+		AssetsRoot:    filepath.Join(inst.Path, "UserData"),
+		NativesDir:    filepath.Join(inst.Path, "natives", fmt.Sprintf("%d", inst.Version)),
+		VersionName:   fmt.Sprintf("%s-%d", inst.VersionType, inst.Version),
+		JavaExec:      resolveJavaExec(inst.JavaPath),
+		Features:      features,
+	}
+
+	resolved, err := launch.Resolve(manifest, ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolved.JavaArgs = append(resolved.JavaArgs, inst.JVMArgs...)
+	return resolved, nil
+}
+
+// resolveJavaExec returns override (an instance's JavaPath) when set,
+// otherwise the first runtime java.Locate() finds, otherwise the bundled
+// JRE's java binary path - the same fallback chain game.LaunchNamedInstance
+// used before this package took over launching.
+func resolveJavaExec(override string) string {
+	if override != "" {
+		return override
+	}
+	if runtimes := java.Locate(); len(runtimes) > 0 {
+		return runtimes[0].Path
+	}
+
+	jreDir := filepath.Join(env.GetDefaultAppDir(), "jre")
+	switch runtime.GOOS {
+	case "windows":
+		return filepath.Join(jreDir, "bin", "java.exe")
+	default:
+		return filepath.Join(jreDir, "bin", "java")
+	}
+}
+
+// launchResolved execs the client binary for inst with the resolved JVM and
+// game arguments.
+func launchResolved(inst *instance.Instance, resolved *launch.Resolved) error {
+	clientPath := clientExecutablePathFor(inst.Path)
+	if _, err := os.Stat(clientPath); err != nil {
+		return fmt.Errorf("game client not found at %s (instance %q not installed): %w", clientPath, inst.Name, err)
+	}
+
+	args := append(append([]string{}, resolved.JavaArgs...), resolved.GameArgs...)
+	cmd := exec.Command(clientPath, args...)
+	cmd.Dir = inst.Path
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+
+	return cmd.Start()
+}
+
+func clientExecutablePathFor(instanceDir string) string {
+	switch runtime.GOOS {
+	case "darwin":
+		return filepath.Join(instanceDir, "Client", "Hytale.app", "Contents", "MacOS", "HytaleClient")
+	case "windows":
+		return filepath.Join(instanceDir, "Client", "HytaleClient.exe")
+	default:
+		return filepath.Join(instanceDir, "Client", "HytaleClient")
+	}
+}
+
+// Launch resolves and starts an instance's client process, honoring
+// per-launch feature toggles (e.g. windowed/fullscreen, offline mode)
+// instead of the previous hard-coded argument list.
+func (a *App) Launch(instanceID string, features map[string]bool) error {
+	inst, err := instance.Get(instanceID)
+	if err != nil {
+		return err
+	}
+
+	resolved, err := a.BuildLaunchCommand(instanceID, features)
+	if err != nil {
+		return err
+	}
+
+	if err := launchResolved(inst, resolved); err != nil {
+		return err
+	}
+
+	if err := instance.TouchLastPlayed(inst.ID); err != nil {
+		fmt.Printf("warning: failed to record last-played time for %q: %v\n", inst.Name, err)
+	}
+
+	return nil
+}
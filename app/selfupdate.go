@@ -0,0 +1,21 @@
+package app
+
+import (
+	"HyPrism/internal/selfupdate"
+)
+
+// CheckForLauncherUpdate returns the latest published HyPrism release so
+// the UI can show a "new version available" banner next to the game
+// version info.
+func (a *App) CheckForLauncherUpdate() (*selfupdate.Release, error) {
+	return selfupdate.CheckForUpdate()
+}
+
+// UpdateLauncher downloads and applies the latest (or a pinned) HyPrism
+// release, replacing the running binary.
+func (a *App) UpdateLauncher(version string, dryRun bool) error {
+	return selfupdate.SelfUpdate(selfupdate.UpdateOptions{
+		Version: version,
+		DryRun:  dryRun,
+	})
+}
@@ -0,0 +1,29 @@
+package app
+
+import (
+	"context"
+
+	"HyPrism/internal/updater"
+)
+
+// CheckForLauncherReleaseUpdate checks GitHub releases for a newer build
+// than the one currently running, returning nil if there isn't one. Unlike
+// CheckForLauncherUpdate, this surfaces release notes and size up front so
+// the UI can show them before the user opts into downloading.
+func (a *App) CheckForLauncherReleaseUpdate() (*updater.UpdateInfo, error) {
+	return updater.CheckForUpdate(context.Background())
+}
+
+// DownloadLauncherUpdate downloads the release described by info to a temp
+// file and returns its path, to be passed to ApplyLauncherUpdate.
+func (a *App) DownloadLauncherUpdate(info *updater.UpdateInfo) (string, error) {
+	return updater.DownloadUpdate(context.Background(), info, nil)
+}
+
+// ApplyLauncherUpdate verifies and swaps in an update downloaded via
+// DownloadLauncherUpdate, restarting the launcher. info must be the same
+// value returned by CheckForLauncherReleaseUpdate, so the checksum and
+// signature it carries match the archive being applied.
+func (a *App) ApplyLauncherUpdate(info *updater.UpdateInfo, downloadedArchivePath string) error {
+	return updater.ApplyUpdate(info, downloadedArchivePath)
+}
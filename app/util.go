@@ -2,6 +2,7 @@ package app
 
 import (
 	"HyPrism/internal/env"
+	"HyPrism/internal/instance"
 	"fmt"
 	"os"
 	"os/exec"
@@ -38,11 +39,16 @@ func (a *App) OpenFolder() error {
 	return nil
 }
 
-// RepairInstallation cleans up corrupted/incomplete installation files
-// This is useful when butler fails with "Access Denied" errors
-func (a *App) RepairInstallation() error {
-	gameDir := env.GetInstanceGameDir("release", 0)
-	
+// RepairInstallation cleans up corrupted/incomplete installation files for
+// the given instance. This is useful when butler fails with "Access Denied"
+// errors.
+func (a *App) RepairInstallation(instanceID string) error {
+	inst, err := instance.Get(instanceID)
+	if err != nil {
+		return FileSystemError("locating instance", err)
+	}
+	gameDir := inst.Path
+
 	// Clean staging directory
 	stagingDir := filepath.Join(gameDir, "staging-temp")
 	if err := os.RemoveAll(stagingDir); err != nil {
@@ -79,37 +85,44 @@ func (a *App) RepairInstallation() error {
 	return nil
 }
 
-// DeleteGame deletes the game installation
-func (a *App) DeleteGame() error {
-	homeDir := env.GetDefaultAppDir()
+// DeleteGame deletes an instance's installed game files, without removing
+// the instance itself from the manifest - use DeleteInstance for that. This
+// lets a corrupted install be wiped and reinstalled without losing the
+// instance's settings (profile, JavaPath, JVMArgs, ...).
+func (a *App) DeleteGame(instanceID string) error {
+	inst, err := instance.Get(instanceID)
+	if err != nil {
+		return FileSystemError("locating instance", err)
+	}
 
-	entries, err := os.ReadDir(homeDir)
+	entries, err := os.ReadDir(inst.Path)
 	if err != nil {
-		return FileSystemError("reading game directory", err)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return FileSystemError("reading instance directory", err)
 	}
 
 	// Track deletion errors
 	var deleteErrors []string
 
 	for _, entry := range entries {
-		if entry.IsDir() {
-			dirPath := filepath.Join(homeDir, entry.Name())
-			if err := os.RemoveAll(dirPath); err != nil {
-				deleteErrors = append(deleteErrors, entry.Name())
-			}
+		entryPath := filepath.Join(inst.Path, entry.Name())
+		if err := os.RemoveAll(entryPath); err != nil {
+			deleteErrors = append(deleteErrors, entry.Name())
 		}
 	}
 
 	if len(deleteErrors) > 0 {
 		return GameError(
-			fmt.Sprintf("Failed to delete some folders: %v", deleteErrors),
+			fmt.Sprintf("Failed to delete some files: %v", deleteErrors),
 			nil,
 		)
 	}
 
-	// Recreate folder structure
-	if err := env.CreateFolders(); err != nil {
-		return FileSystemError("recreating folder structure", err)
+	// Recreate the (now empty) instance directory
+	if err := os.MkdirAll(inst.Path, 0755); err != nil {
+		return FileSystemError("recreating instance directory", err)
 	}
 
 	return nil
@@ -0,0 +1,213 @@
+package crashreport
+
+import (
+	"bufio"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Parse sniffs content to determine which dialect a crash log is, then
+// extracts a structured ParsedCrash from it. filename is carried through
+// only for display/export purposes.
+func Parse(filename string, content []byte) *ParsedCrash {
+	text := string(content)
+
+	switch {
+	case strings.Contains(text, "A fatal error has been detected by the Java Runtime Environment"):
+		return parseJVMFatalError(filename, text)
+	case jvmExceptionHeader.MatchString(text):
+		return parseJavaException(filename, text)
+	case strings.HasPrefix(strings.TrimSpace(text), "panic:"):
+		return parseLauncherPanic(filename, text)
+	default:
+		crash := &ParsedCrash{Filename: filename, Type: TypeUnknown}
+		crash.Signature = signature(crash)
+		return crash
+	}
+}
+
+var (
+	jvmExceptionHeader = regexp.MustCompile(`(?m)^Exception in thread "([^"]*)" ([\w.$]+)(?::\s*(.*))?$`)
+	javaAtFrame         = regexp.MustCompile(`^\s*at ([\w.$<>]+)\.([\w<>$]+)\(([^)]*)\)`)
+	javaCausedBy        = regexp.MustCompile(`^Caused by: ([\w.$]+)(?::\s*(.*))?$`)
+	jvmProblematicFrame = regexp.MustCompile(`#\s*Problematic frame:\s*\n#\s*(.*)`)
+	jvmThreadHeader     = regexp.MustCompile(`Current thread \(0x[0-9a-fA-F]+\):\s*\S+\s+"([^"]*)"`)
+	jvmNativeFrame      = regexp.MustCompile(`^[A-Za-z]\s+\[([^+\]]+)(?:\+0x[0-9a-fA-F]+)?\]\s*(.*)`)
+	jvmTimestamp        = regexp.MustCompile(`time: (.+)`)
+	goPanicFrame        = regexp.MustCompile(`^\t(.+\.go):(\d+)`)
+	goPanicSymbol       = regexp.MustCompile(`^([\w./*()]+)\(`)
+)
+
+// parseJavaException handles an uncaught-exception stack trace printed to
+// stdout/stderr by the Hytale client, including a "Caused by" chain.
+func parseJavaException(filename, text string) *ParsedCrash {
+	crash := &ParsedCrash{Filename: filename, Type: TypeJavaException}
+
+	m := jvmExceptionHeader.FindStringSubmatch(text)
+	if m != nil {
+		crash.Thread = m[1]
+		crash.ExceptionClass = m[2]
+		crash.Message = strings.TrimSpace(m[3])
+	}
+
+	lines := strings.Split(text, "\n")
+	crash.Frames = parseJavaFrames(lines)
+	crash.CausedBy = parseCausedBy(filename, lines)
+	crash.Signature = signature(crash)
+	return crash
+}
+
+func parseJavaFrames(lines []string) []StackFrame {
+	var frames []StackFrame
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "Caused by:") {
+			break
+		}
+		m := javaAtFrame.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		frames = append(frames, StackFrame{
+			Module: m[1],
+			Symbol: m[2],
+			Source: m[3],
+		})
+	}
+	return frames
+}
+
+func parseCausedBy(filename string, lines []string) *ParsedCrash {
+	for i, line := range lines {
+		m := javaCausedBy.FindStringSubmatch(strings.TrimRight(line, "\r"))
+		if m == nil {
+			continue
+		}
+		cause := &ParsedCrash{
+			Filename:       filename,
+			Type:           TypeJavaException,
+			ExceptionClass: m[1],
+			Message:        strings.TrimSpace(m[2]),
+			Frames:         parseJavaFrames(lines[i+1:]),
+		}
+		cause.Signature = signature(cause)
+		return cause
+	}
+	return nil
+}
+
+// parseJVMFatalError handles an hs_err_pid*.log crash dump written by the
+// JVM itself on a native-level fault (segfault, OOM in native code, ...).
+func parseJVMFatalError(filename, text string) *ParsedCrash {
+	crash := &ParsedCrash{Filename: filename, Type: TypeJVMFatalError}
+
+	if m := jvmProblematicFrame.FindStringSubmatch(text); m != nil {
+		crash.Message = strings.TrimSpace(m[1])
+	}
+	if m := jvmThreadHeader.FindStringSubmatch(text); m != nil {
+		crash.Thread = m[1]
+	}
+	if m := jvmTimestamp.FindStringSubmatch(text); m != nil {
+		if ts, err := time.Parse("Mon Jan 2 15:04:05 2006", strings.TrimSpace(m[1])); err == nil {
+			crash.Timestamp = ts
+		}
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	inNativeFrames := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "Native frames:"):
+			inNativeFrames = true
+			continue
+		case strings.HasPrefix(line, "Java frames:"), strings.TrimSpace(line) == "":
+			if inNativeFrames {
+				inNativeFrames = false
+			}
+		}
+		if !inNativeFrames {
+			continue
+		}
+		m := jvmNativeFrame.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		crash.Frames = append(crash.Frames, StackFrame{Module: m[1], Symbol: strings.TrimSpace(m[2])})
+	}
+
+	if idx := strings.Index(text, "---------------  S Y S T E M  ---------------"); idx != -1 {
+		end := strings.Index(text[idx:], "\n\n")
+		if end == -1 {
+			end = len(text) - idx
+		}
+		crash.SystemInfo = strings.TrimSpace(text[idx : idx+end])
+	}
+
+	crash.Modules = parseLoadedModules(text)
+	crash.Signature = signature(crash)
+	return crash
+}
+
+func parseLoadedModules(text string) []LoadedModule {
+	idx := strings.Index(text, "Dynamic libraries:")
+	if idx == -1 {
+		return nil
+	}
+	var modules []LoadedModule
+	scanner := bufio.NewScanner(strings.NewReader(text[idx:]))
+	scanner.Scan() // skip the header line itself
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			break
+		}
+		fields := strings.Fields(line)
+		path := fields[len(fields)-1]
+		parts := strings.Split(strings.ReplaceAll(path, "\\", "/"), "/")
+		modules = append(modules, LoadedModule{Name: parts[len(parts)-1], Path: path})
+	}
+	return modules
+}
+
+// parseLauncherPanic handles a Go panic trace from HyPrism's own process.
+func parseLauncherPanic(filename, text string) *ParsedCrash {
+	crash := &ParsedCrash{Filename: filename, Type: TypeLauncherPanic}
+
+	lines := strings.Split(text, "\n")
+	if len(lines) > 0 {
+		crash.Message = strings.TrimPrefix(strings.TrimSpace(lines[0]), "panic: ")
+	}
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "goroutine ") {
+			continue
+		}
+		crash.Thread = trimmed
+		crash.Frames = parseGoFrames(lines[i+1:])
+		break
+	}
+
+	crash.Signature = signature(crash)
+	return crash
+}
+
+func parseGoFrames(lines []string) []StackFrame {
+	var frames []StackFrame
+	var pendingSymbol string
+	for _, line := range lines {
+		if strings.HasPrefix(line, "\t") {
+			m := goPanicFrame.FindStringSubmatch(line)
+			if m != nil && pendingSymbol != "" {
+				frames = append(frames, StackFrame{Symbol: pendingSymbol, Source: m[1] + ":" + m[2]})
+				pendingSymbol = ""
+			}
+			continue
+		}
+		if m := goPanicSymbol.FindStringSubmatch(line); m != nil {
+			pendingSymbol = m[1]
+		}
+	}
+	return frames
+}
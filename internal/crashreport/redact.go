@@ -0,0 +1,28 @@
+package crashreport
+
+import "regexp"
+
+// redactPatterns strips information from a crash bundle that shouldn't
+// leave the user's machine in a bug report: OS usernames embedded in
+// paths, and tokens that look like API keys/secrets.
+var redactPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(C:\\Users\\)[^\\]+`),
+	regexp.MustCompile(`(/home/)[^/]+`),
+	regexp.MustCompile(`(/Users/)[^/]+`),
+	regexp.MustCompile(`\b(?:sk|pk|ghp|gho|ghu|ghs|ghr)_[A-Za-z0-9]{20,}\b`),
+	regexp.MustCompile(`\b[A-Za-z0-9_-]{32,}\.[A-Za-z0-9_-]{6,}\.[A-Za-z0-9_-]{20,}\b`), // JWT-shaped
+	regexp.MustCompile(`(?i)(api[_-]?key|token|secret)["':=\s]+[A-Za-z0-9_\-./+]{12,}`),
+}
+
+// Redact strips usernames from common path prefixes and common
+// secret-shaped tokens from text, replacing them with "<redacted>".
+func Redact(text string) string {
+	for _, re := range redactPatterns {
+		if re.NumSubexp() > 0 {
+			text = re.ReplaceAllString(text, "${1}<redacted>")
+		} else {
+			text = re.ReplaceAllString(text, "<redacted>")
+		}
+	}
+	return text
+}
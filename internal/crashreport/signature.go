@@ -0,0 +1,99 @@
+package crashreport
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+// signatureFrameCount is how many non-JDK/runtime frames go into the
+// signature hash. Deep enough to tell unrelated bugs apart, shallow
+// enough that an extra frame added by an unrelated library upgrade
+// doesn't fracture the group.
+const signatureFrameCount = 5
+
+// jdkModulePrefixes are frames common to almost every JVM crash,
+// regardless of the actual bug - excluding them keeps the signature
+// focused on the HyPrism/Hytale code that's actually at fault.
+var jdkModulePrefixes = []string{
+	"java.", "javax.", "jdk.", "sun.", "com.sun.",
+}
+
+// signature computes a stable grouping key for a crash: the exception
+// class (if any) plus a hash of its top non-runtime frames. Two crashes
+// with the same signature are treated as "the same crash" by GroupBySignature.
+func signature(crash *ParsedCrash) string {
+	var sb strings.Builder
+	sb.WriteString(string(crash.Type))
+	sb.WriteString("|")
+	sb.WriteString(crash.ExceptionClass)
+
+	count := 0
+	for _, f := range crash.Frames {
+		if count >= signatureFrameCount {
+			break
+		}
+		if isRuntimeFrame(f) {
+			continue
+		}
+		sb.WriteString("|")
+		sb.WriteString(f.Module)
+		sb.WriteString(".")
+		sb.WriteString(f.Symbol)
+		count++
+	}
+
+	sum := sha256.Sum256([]byte(sb.String()))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+func isRuntimeFrame(f StackFrame) bool {
+	for _, prefix := range jdkModulePrefixes {
+		if strings.HasPrefix(f.Module, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// GroupBySignature collapses a flat list of parsed crashes into groups
+// sharing the same Signature, newest-first within each group and
+// sorted by most recent crash across groups.
+func GroupBySignature(crashes []ParsedCrash) []Group {
+	index := make(map[string]*Group)
+	var order []string
+
+	for _, crash := range crashes {
+		g, ok := index[crash.Signature]
+		if !ok {
+			g = &Group{Signature: crash.Signature}
+			index[crash.Signature] = g
+			order = append(order, crash.Signature)
+		}
+		g.Crashes = append(g.Crashes, crash)
+		g.Count++
+		if g.Latest == nil || crash.Timestamp.After(g.Latest.Timestamp) {
+			latest := crash
+			g.Latest = &latest
+		}
+	}
+
+	groups := make([]Group, 0, len(order))
+	for _, sig := range order {
+		g := index[sig]
+		sort.SliceStable(g.Crashes, func(i, j int) bool {
+			return g.Crashes[i].Timestamp.After(g.Crashes[j].Timestamp)
+		})
+		groups = append(groups, *g)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Latest == nil || groups[j].Latest == nil {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Latest.Timestamp.After(groups[j].Latest.Timestamp)
+	})
+
+	return groups
+}
@@ -0,0 +1,57 @@
+// Package crashreport parses the two crash-log dialects HyPrism can
+// produce - JVM hs_err/exception dumps from the Hytale client, and Go
+// panic traces from the launcher itself - into a common structured form
+// so the UI can group repeated crashes and export a bug-report bundle.
+package crashreport
+
+import "time"
+
+// Type identifies which dialect a crash report was parsed as.
+type Type string
+
+const (
+	TypeJVMFatalError  Type = "jvm-fatal-error" // hs_err_pid*.log
+	TypeJavaException  Type = "java-exception"  // uncaught exception printed to stdout/stderr
+	TypeLauncherPanic  Type = "launcher-panic"   // Go panic trace from HyPrism itself
+	TypeUnknown        Type = "unknown"
+)
+
+// StackFrame is one frame of a parsed stack trace.
+type StackFrame struct {
+	Module string `json:"module"` // class or native module the frame belongs to
+	Symbol string `json:"symbol"`
+	Source string `json:"source,omitempty"` // "File.java:42" or "/path/file.go:123"
+}
+
+// LoadedModule is one entry from a JVM crash's loaded-library list.
+type LoadedModule struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// ParsedCrash is the structured form of a raw crash log.
+type ParsedCrash struct {
+	Filename       string         `json:"filename"`
+	Type           Type           `json:"type"`
+	Timestamp      time.Time      `json:"timestamp"`
+	Thread         string         `json:"thread,omitempty"`
+	ExceptionClass string         `json:"exceptionClass,omitempty"`
+	Message        string         `json:"message,omitempty"`
+	Frames         []StackFrame   `json:"frames"`
+	Modules        []LoadedModule `json:"modules,omitempty"`
+	SystemInfo     string         `json:"systemInfo,omitempty"`
+	CausedBy       *ParsedCrash   `json:"causedBy,omitempty"`
+
+	// Signature groups crashes that are "the same" despite differing
+	// timestamps/threads: a hash of the exception class plus the top N
+	// non-JDK/runtime frames. See signature().
+	Signature string `json:"signature"`
+}
+
+// Group is a set of crash reports that share a Signature.
+type Group struct {
+	Signature string        `json:"signature"`
+	Count     int           `json:"count"`
+	Latest    *ParsedCrash  `json:"latest"`
+	Crashes   []ParsedCrash `json:"crashes"`
+}
@@ -0,0 +1,54 @@
+// Package disk abstracts filesystem access behind an interface so instances
+// can live somewhere other than the local disk (an FTP or SFTP remote),
+// instead of every install/repair path calling os.* directly.
+package disk
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"strings"
+)
+
+// Disk is the filesystem surface install/repair code needs. Paths passed in
+// are always relative to the disk's root, never absolute host paths.
+type Disk interface {
+	Read(path string) ([]byte, error)
+	Write(path string, data []byte, perm fs.FileMode) error
+	// WriteStream writes r to path without buffering it fully in memory
+	// first, for uploading large files (e.g. a PWR-applied Client tree).
+	WriteStream(path string, r io.Reader, perm fs.FileMode) error
+	Exists(path string) bool
+	MkdirAll(path string, perm fs.FileMode) error
+	Remove(path string) error
+	Walk(root string, fn fs.WalkDirFunc) error
+	Open(path string) (io.ReadCloser, error)
+	Stat(path string) (fs.FileInfo, error)
+	Rename(oldPath, newPath string) error
+	Close() error
+}
+
+// Open resolves a disk URL (file://, sftp://, ftp://) into a Disk
+// implementation. Instances store this URL as their diskURL.
+func Open(diskURL string) (Disk, error) {
+	if diskURL == "" || !strings.Contains(diskURL, "://") {
+		return newLocalDisk(diskURL), nil
+	}
+
+	u, err := url.Parse(diskURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing disk URL: %w", err)
+	}
+
+	switch u.Scheme {
+	case "file", "":
+		return newLocalDisk(u.Path), nil
+	case "sftp":
+		return newSFTPDisk(u)
+	case "ftp":
+		return newFTPDisk(u)
+	default:
+		return nil, fmt.Errorf("unsupported disk scheme %q", u.Scheme)
+	}
+}
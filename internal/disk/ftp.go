@@ -0,0 +1,177 @@
+package disk
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/jackc/puddle/v2"
+	"github.com/jlaffaye/ftp"
+)
+
+// ftpDisk talks to a remote instance directory over plain FTP, pooled the
+// same way sftpDisk is.
+type ftpDisk struct {
+	root string
+	pool *puddle.Pool[*ftp.ServerConn]
+}
+
+func newFTPDisk(u *url.URL) (*ftpDisk, error) {
+	addr := u.Host
+	user := u.User.Username()
+	password, _ := u.User.Password()
+
+	constructor := func(ctx context.Context) (*ftp.ServerConn, error) {
+		conn, err := ftp.Dial(addr, ftp.DialWithTimeout(10*time.Second))
+		if err != nil {
+			return nil, fmt.Errorf("dialing ftp host: %w", err)
+		}
+		if err := conn.Login(user, password); err != nil {
+			conn.Quit()
+			return nil, fmt.Errorf("ftp login: %w", err)
+		}
+		return conn, nil
+	}
+	destructor := func(conn *ftp.ServerConn) {
+		conn.Quit()
+	}
+
+	pool, err := puddle.NewPool(&puddle.Config[*ftp.ServerConn]{
+		Constructor: constructor,
+		Destructor:  destructor,
+		MaxSize:     4,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating ftp connection pool: %w", err)
+	}
+
+	return &ftpDisk{root: u.Path, pool: pool}, nil
+}
+
+func (d *ftpDisk) resolve(p string) string {
+	return path.Join(d.root, p)
+}
+
+func (d *ftpDisk) withConn(fn func(*ftp.ServerConn) error) error {
+	res, err := d.pool.Acquire(context.Background())
+	if err != nil {
+		return fmt.Errorf("acquiring ftp connection: %w", err)
+	}
+	defer res.Release()
+	return fn(res.Value())
+}
+
+func (d *ftpDisk) Read(p string) ([]byte, error) {
+	var data []byte
+	err := d.withConn(func(c *ftp.ServerConn) error {
+		r, err := c.Retr(d.resolve(p))
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		data, err = io.ReadAll(r)
+		return err
+	})
+	return data, err
+}
+
+func (d *ftpDisk) Write(p string, data []byte, perm fs.FileMode) error {
+	return d.withConn(func(c *ftp.ServerConn) error {
+		return c.Stor(d.resolve(p), bytes.NewReader(data))
+	})
+}
+
+func (d *ftpDisk) WriteStream(p string, r io.Reader, perm fs.FileMode) error {
+	return d.withConn(func(c *ftp.ServerConn) error {
+		return c.Stor(d.resolve(p), r)
+	})
+}
+
+func (d *ftpDisk) Exists(p string) bool {
+	var exists bool
+	_ = d.withConn(func(c *ftp.ServerConn) error {
+		_, err := c.FileSize(d.resolve(p))
+		exists = err == nil
+		return nil
+	})
+	return exists
+}
+
+func (d *ftpDisk) MkdirAll(p string, perm fs.FileMode) error {
+	return d.withConn(func(c *ftp.ServerConn) error {
+		return c.MakeDir(d.resolve(p))
+	})
+}
+
+func (d *ftpDisk) Remove(p string) error {
+	return d.withConn(func(c *ftp.ServerConn) error {
+		return c.RemoveDirRecur(d.resolve(p))
+	})
+}
+
+func (d *ftpDisk) Walk(root string, fn fs.WalkDirFunc) error {
+	return d.withConn(func(c *ftp.ServerConn) error {
+		walker := c.Walk(d.resolve(root))
+		for walker.Next() {
+			if err := fn(walker.Path(), fs.FileInfoToDirEntry(walker.Stat()), nil); err != nil {
+				return err
+			}
+		}
+		return walker.Err()
+	})
+}
+
+// Open returns a live reader bound to a pooled connection. FTP allows only
+// one transfer per control connection, so unlike the other methods here
+// (which release their connection as soon as the call returns), the
+// connection backing this reader must stay checked out of the pool until
+// the caller closes it - releasing early would hand an in-flight transfer's
+// connection to another goroutine and corrupt or hang both transfers.
+func (d *ftpDisk) Open(p string) (io.ReadCloser, error) {
+	res, err := d.pool.Acquire(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("acquiring ftp connection: %w", err)
+	}
+
+	r, err := res.Value().Retr(d.resolve(p))
+	if err != nil {
+		res.Release()
+		return nil, err
+	}
+
+	return &releaseOnCloseReader{ReadCloser: r, res: res}, nil
+}
+
+// releaseOnCloseReader defers releasing a pooled FTP connection back to the
+// pool until the wrapped reader is closed, instead of when withConn's defer
+// would otherwise release it.
+type releaseOnCloseReader struct {
+	io.ReadCloser
+	res *puddle.Resource[*ftp.ServerConn]
+}
+
+func (r *releaseOnCloseReader) Close() error {
+	err := r.ReadCloser.Close()
+	r.res.Release()
+	return err
+}
+
+func (d *ftpDisk) Stat(p string) (fs.FileInfo, error) {
+	return nil, fmt.Errorf("ftp: Stat is not supported, use Exists")
+}
+
+func (d *ftpDisk) Rename(oldPath, newPath string) error {
+	return d.withConn(func(c *ftp.ServerConn) error {
+		return c.Rename(d.resolve(oldPath), d.resolve(newPath))
+	})
+}
+
+func (d *ftpDisk) Close() error {
+	d.pool.Close()
+	return nil
+}
@@ -0,0 +1,86 @@
+package disk
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// localDisk is the default Disk backed by the host filesystem. It's what
+// every instance used before remote disks existed, and remains the only
+// implementation that butler apply can target directly (see note on
+// ApplyPWRToRemoteDisk in internal/pwr).
+type localDisk struct {
+	root string
+}
+
+func newLocalDisk(root string) *localDisk {
+	return &localDisk{root: root}
+}
+
+func (d *localDisk) resolve(path string) string {
+	if d.root == "" {
+		return path
+	}
+	return filepath.Join(d.root, path)
+}
+
+func (d *localDisk) Read(path string) ([]byte, error) {
+	return os.ReadFile(d.resolve(path))
+}
+
+func (d *localDisk) Write(path string, data []byte, perm fs.FileMode) error {
+	full := d.resolve(path)
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(full, data, perm)
+}
+
+func (d *localDisk) WriteStream(path string, r io.Reader, perm fs.FileMode) error {
+	full := d.resolve(path)
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(full, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	_, err = io.Copy(out, r)
+	return err
+}
+
+func (d *localDisk) Exists(path string) bool {
+	_, err := os.Stat(d.resolve(path))
+	return err == nil
+}
+
+func (d *localDisk) MkdirAll(path string, perm fs.FileMode) error {
+	return os.MkdirAll(d.resolve(path), perm)
+}
+
+func (d *localDisk) Remove(path string) error {
+	return os.RemoveAll(d.resolve(path))
+}
+
+func (d *localDisk) Walk(root string, fn fs.WalkDirFunc) error {
+	return filepath.WalkDir(d.resolve(root), fn)
+}
+
+func (d *localDisk) Open(path string) (io.ReadCloser, error) {
+	return os.Open(d.resolve(path))
+}
+
+func (d *localDisk) Stat(path string) (fs.FileInfo, error) {
+	return os.Stat(d.resolve(path))
+}
+
+func (d *localDisk) Rename(oldPath, newPath string) error {
+	return os.Rename(d.resolve(oldPath), d.resolve(newPath))
+}
+
+func (d *localDisk) Close() error {
+	return nil
+}
@@ -0,0 +1,187 @@
+package disk
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"path"
+
+	"github.com/jackc/puddle/v2"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+// sftpDisk talks to a remote instance directory over SFTP. Connections are
+// pooled with puddle so a RepairInstallation walk doesn't open a fresh
+// control channel per call.
+type sftpDisk struct {
+	root string
+	pool *puddle.Pool[*sftp.Client]
+}
+
+func newSFTPDisk(u *url.URL) (*sftpDisk, error) {
+	addr := u.Host
+	user := u.User.Username()
+	password, _ := u.User.Password()
+
+	config := &ssh.ClientConfig{
+		User:            user,
+		Auth:            []ssh.AuthMethod{ssh.Password(password)},
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(), // TODO: verify against known_hosts
+	}
+
+	constructor := func(ctx context.Context) (*sftp.Client, error) {
+		conn, err := ssh.Dial("tcp", addr, config)
+		if err != nil {
+			return nil, fmt.Errorf("dialing sftp host: %w", err)
+		}
+		client, err := sftp.NewClient(conn)
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("starting sftp session: %w", err)
+		}
+		return client, nil
+	}
+	destructor := func(client *sftp.Client) {
+		client.Close()
+	}
+
+	pool, err := puddle.NewPool(&puddle.Config[*sftp.Client]{
+		Constructor: constructor,
+		Destructor:  destructor,
+		MaxSize:     4,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating sftp connection pool: %w", err)
+	}
+
+	return &sftpDisk{root: u.Path, pool: pool}, nil
+}
+
+func (d *sftpDisk) resolve(p string) string {
+	return path.Join(d.root, p)
+}
+
+func (d *sftpDisk) withClient(fn func(*sftp.Client) error) error {
+	res, err := d.pool.Acquire(context.Background())
+	if err != nil {
+		return fmt.Errorf("acquiring sftp connection: %w", err)
+	}
+	defer res.Release()
+	return fn(res.Value())
+}
+
+func (d *sftpDisk) Read(p string) ([]byte, error) {
+	var data []byte
+	err := d.withClient(func(c *sftp.Client) error {
+		f, err := c.Open(d.resolve(p))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		data, err = io.ReadAll(f)
+		return err
+	})
+	return data, err
+}
+
+func (d *sftpDisk) Write(p string, data []byte, perm fs.FileMode) error {
+	return d.withClient(func(c *sftp.Client) error {
+		if err := c.MkdirAll(path.Dir(d.resolve(p))); err != nil {
+			return err
+		}
+		f, err := c.Create(d.resolve(p))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = f.Write(data)
+		return err
+	})
+}
+
+func (d *sftpDisk) WriteStream(p string, r io.Reader, perm fs.FileMode) error {
+	return d.withClient(func(c *sftp.Client) error {
+		if err := c.MkdirAll(path.Dir(d.resolve(p))); err != nil {
+			return err
+		}
+		f, err := c.Create(d.resolve(p))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(f, r)
+		return err
+	})
+}
+
+func (d *sftpDisk) Exists(p string) bool {
+	err := d.withClient(func(c *sftp.Client) error {
+		_, err := c.Stat(d.resolve(p))
+		return err
+	})
+	return err == nil
+}
+
+func (d *sftpDisk) MkdirAll(p string, perm fs.FileMode) error {
+	return d.withClient(func(c *sftp.Client) error {
+		return c.MkdirAll(d.resolve(p))
+	})
+}
+
+func (d *sftpDisk) Remove(p string) error {
+	return d.withClient(func(c *sftp.Client) error {
+		return c.RemoveAll(d.resolve(p))
+	})
+}
+
+func (d *sftpDisk) Walk(root string, fn fs.WalkDirFunc) error {
+	return d.withClient(func(c *sftp.Client) error {
+		walker := c.Walk(d.resolve(root))
+		for walker.Step() {
+			if err := walker.Err(); err != nil {
+				return err
+			}
+			if err := fn(walker.Path(), fs.FileInfoToDirEntry(walker.Stat()), nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (d *sftpDisk) Open(p string) (io.ReadCloser, error) {
+	var out io.ReadCloser
+	err := d.withClient(func(c *sftp.Client) error {
+		f, err := c.Open(d.resolve(p))
+		if err != nil {
+			return err
+		}
+		out = f
+		return nil
+	})
+	return out, err
+}
+
+func (d *sftpDisk) Stat(p string) (fs.FileInfo, error) {
+	var info fs.FileInfo
+	err := d.withClient(func(c *sftp.Client) error {
+		i, err := c.Stat(d.resolve(p))
+		info = i
+		return err
+	})
+	return info, err
+}
+
+func (d *sftpDisk) Rename(oldPath, newPath string) error {
+	return d.withClient(func(c *sftp.Client) error {
+		return c.Rename(d.resolve(oldPath), d.resolve(newPath))
+	})
+}
+
+func (d *sftpDisk) Close() error {
+	d.pool.Close()
+	return nil
+}
@@ -0,0 +1,338 @@
+// Package download provides a small worker pool for fetching a large asset
+// in parallel HTTP range chunks instead of one slow single-stream GET.
+// Progress across chunks is coalesced into the same (stage, progress, msg,
+// file, speed, downloaded, total) shape the rest of the app already reports
+// through. Currently only pwr.DownloadPWR is wired to it; JRE and Butler
+// fetches still go through their own single-stream downloaders, since
+// neither exposes a resumable/chunkable URL in this tree yet. JRE and
+// Butler installs still run concurrently with each other, just not
+// internally range-split - see internal/game.fetchJREAndButler.
+package download
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ProgressFunc matches the progress callback signature used across the app.
+type ProgressFunc func(stage string, progress float64, msg string, file string, speed string, downloaded, total int64)
+
+// chunkSize is the target size of each range request.
+const chunkSize = 16 * 1024 * 1024 // 16 MiB
+
+// Pool fetches assets using a bounded number of concurrent workers.
+type Pool struct {
+	Workers int
+}
+
+// NewPool returns a Pool sized to min(GOMAXPROCS, 4) workers when n <= 0.
+func NewPool(n int) *Pool {
+	if n <= 0 {
+		n = runtime.GOMAXPROCS(0)
+		if n > 4 {
+			n = 4
+		}
+		if n < 1 {
+			n = 1
+		}
+	}
+	return &Pool{Workers: n}
+}
+
+// Request describes a single asset to fetch.
+type Request struct {
+	URL          string
+	Dest         string
+	ExpectedSize int64  // 0 if unknown
+	ExpectedSHA  string // hex sha256, empty to skip verification
+	File         string // display name for progress callbacks
+}
+
+// partsIndex is the sidecar persisted next to Dest as "<dest>.parts.json"
+// describing which byte ranges have already been written, so a canceled or
+// failed download only refetches what's missing on the next attempt.
+type partsIndex struct {
+	Size       int64       `json:"size"`
+	URL        string      `json:"url"`
+	Completed  []chunkSpan `json:"completed"`
+	completeMu sync.Mutex  `json:"-"`
+}
+
+type chunkSpan struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"` // inclusive
+}
+
+func partsPath(dest string) string { return dest + ".parts.json" }
+
+func loadPartsIndex(dest string) *partsIndex {
+	data, err := os.ReadFile(partsPath(dest))
+	if err != nil {
+		return &partsIndex{}
+	}
+	var idx partsIndex
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return &partsIndex{}
+	}
+	return &idx
+}
+
+func (p *partsIndex) save(dest string) {
+	p.completeMu.Lock()
+	defer p.completeMu.Unlock()
+	data, err := json.Marshal(p)
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(partsPath(dest), data, 0644)
+}
+
+func (p *partsIndex) isComplete(span chunkSpan) bool {
+	p.completeMu.Lock()
+	defer p.completeMu.Unlock()
+	for _, c := range p.Completed {
+		if c.Start == span.Start && c.End == span.End {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *partsIndex) markComplete(span chunkSpan) {
+	p.completeMu.Lock()
+	defer p.completeMu.Unlock()
+	p.Completed = append(p.Completed, span)
+}
+
+// Fetch downloads req.URL into req.Dest across p.Workers concurrent range
+// requests, resuming from any previously completed chunks recorded in the
+// .parts.json sidecar. On success (and SHA verification, if requested) the
+// sidecar is removed.
+func (p *Pool) Fetch(ctx context.Context, req Request, progress ProgressFunc) error {
+	total := req.ExpectedSize
+	if total == 0 {
+		size, err := headContentLength(ctx, req.URL)
+		if err != nil {
+			return fmt.Errorf("probing content length: %w", err)
+		}
+		total = size
+	}
+
+	f, err := os.OpenFile(req.Dest, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return fmt.Errorf("creating download file: %w", err)
+	}
+	defer f.Close()
+	if err := f.Truncate(total); err != nil {
+		return fmt.Errorf("preallocating download file: %w", err)
+	}
+
+	idx := loadPartsIndex(req.Dest)
+	idx.Size = total
+	idx.URL = req.URL
+
+	spans := splitSpans(total, chunkSize)
+
+	var downloaded int64
+	for _, s := range spans {
+		if idx.isComplete(s) {
+			atomic.AddInt64(&downloaded, s.End-s.Start+1)
+		}
+	}
+
+	jobs := make(chan chunkSpan)
+	var wg sync.WaitGroup
+	errCh := make(chan error, p.Workers)
+
+	var lastUpdate atomic.Int64
+	lastUpdate.Store(time.Now().UnixNano())
+	var lastDownloaded atomic.Int64
+	lastDownloaded.Store(downloaded)
+
+	for w := 0; w < p.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for span := range jobs {
+				if idx.isComplete(span) {
+					continue
+				}
+				if err := fetchSpan(ctx, req.URL, f, span); err != nil {
+					select {
+					case errCh <- err:
+					default:
+					}
+					continue
+				}
+				idx.markComplete(span)
+				idx.save(req.Dest)
+
+				n := atomic.AddInt64(&downloaded, span.End-span.Start+1)
+				now := time.Now()
+				if now.UnixNano()-lastUpdate.Load() >= int64(100*time.Millisecond) {
+					prevTime := lastUpdate.Swap(now.UnixNano())
+					prevN := lastDownloaded.Swap(n)
+					elapsed := time.Duration(now.UnixNano() - prevTime).Seconds()
+					speed := float64(n-prevN) / maxFloat(elapsed, 0.001)
+					if progress != nil {
+						progress("download", float64(n)/float64(total)*100, "Downloading...", req.File, formatSpeed(speed), n, total)
+					}
+				}
+			}
+		}()
+	}
+
+	for _, s := range spans {
+		jobs <- s
+	}
+	close(jobs)
+	wg.Wait()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("segmented download failed: %w", err)
+	default:
+	}
+
+	if progress != nil {
+		progress("download", 100, "Download complete", req.File, "", total, total)
+	}
+
+	if req.ExpectedSHA != "" {
+		if err := verifyFile(req.Dest, req.ExpectedSHA); err != nil {
+			return err
+		}
+	}
+
+	_ = os.Remove(partsPath(req.Dest))
+	return nil
+}
+
+func splitSpans(total int64, size int64) []chunkSpan {
+	var spans []chunkSpan
+	for start := int64(0); start < total; start += size {
+		end := start + size - 1
+		if end >= total {
+			end = total - 1
+		}
+		spans = append(spans, chunkSpan{Start: start, End: end})
+	}
+	if len(spans) == 0 {
+		spans = append(spans, chunkSpan{Start: 0, End: 0})
+	}
+	return spans
+}
+
+func fetchSpan(ctx context.Context, url string, f *os.File, span chunkSpan) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", span.Start, span.End))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// A bare 200 means the server ignored our Range header and is about to
+	// hand us the whole file - writing that at span.Start would silently
+	// scribble the full body over this chunk's offset. Only 206 proves the
+	// server actually honored the range; callers that can't confirm
+	// Accept-Ranges up front should fall back to a single-stream download
+	// instead of calling Fetch at all.
+	if resp.StatusCode != http.StatusPartialContent {
+		return fmt.Errorf("server did not honor range request: status %d for range %d-%d", resp.StatusCode, span.Start, span.End)
+	}
+
+	// Clamp the read to the span's size as defense in depth, in case a
+	// misbehaving server returns a 206 with a larger range than requested.
+	body := io.LimitReader(resp.Body, span.End-span.Start+1)
+
+	buf := make([]byte, 256*1024)
+	offset := span.Start
+	for {
+		n, rerr := body.Read(buf)
+		if n > 0 {
+			if _, werr := f.WriteAt(buf[:n], offset); werr != nil {
+				return werr
+			}
+			offset += int64(n)
+		}
+		if rerr != nil {
+			if rerr == io.EOF {
+				break
+			}
+			return rerr
+		}
+	}
+	return nil
+}
+
+func headContentLength(ctx context.Context, url string) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return resp.ContentLength, nil
+}
+
+func verifyFile(path, expectedSHA string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening file to verify: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	buf := make([]byte, 1024*1024)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			h.Write(buf[:n])
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	got := hex.EncodeToString(h.Sum(nil))
+	if got != expectedSHA {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", expectedSHA, got)
+	}
+	return nil
+}
+
+func maxFloat(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func formatSpeed(bytesPerSec float64) string {
+	switch {
+	case bytesPerSec < 1024:
+		return fmt.Sprintf("%.0f B/s", bytesPerSec)
+	case bytesPerSec < 1024*1024:
+		return fmt.Sprintf("%.1f KB/s", bytesPerSec/1024)
+	default:
+		return fmt.Sprintf("%.1f MB/s", bytesPerSec/(1024*1024))
+	}
+}
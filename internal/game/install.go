@@ -2,18 +2,28 @@ package game
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
 	"sync"
 
+	"HyPrism/internal/disk"
 	"HyPrism/internal/env"
+	"HyPrism/internal/instance"
 	"HyPrism/internal/java"
 	"HyPrism/internal/pwr"
 	"HyPrism/internal/pwr/butler"
 )
 
+// installMutex/isInstalling guard the legacy single-instance entry points
+// below (EnsureInstalled, EnsureInstalledVersion, EnsureInstalledVersionSpecific).
+// New code should install against a specific instance ID via InstallInstance,
+// which tracks in-flight installs per instance (see internal/instance) so one
+// instance can install while another is being played.
 var (
 	installMutex sync.Mutex
 	isInstalling bool
@@ -36,14 +46,10 @@ func EnsureInstalled(ctx context.Context, progress func(stage string, progress f
 		installMutex.Unlock()
 	}()
 
-	// Download JRE
-	if err := java.DownloadJRE(ctx, progress); err != nil {
-		return fmt.Errorf("failed to download Java Runtime: %w", err)
-	}
-
-	// Install Butler (required for PWR patch extraction)
-	if _, err := butler.InstallButler(ctx, progress); err != nil {
-		return fmt.Errorf("failed to install Butler tool: %w", err)
+	// JRE and Butler don't depend on each other, so fetch them concurrently
+	// instead of serializing two independent multi-minute downloads.
+	if err := fetchJREAndButler(ctx, progress); err != nil {
+		return err
 	}
 
 	// Find latest version with details
@@ -89,7 +95,7 @@ func EnsureInstalled(ctx context.Context, progress func(stage string, progress f
 	}
 
 	// Install/update the game to auto-updating release-latest instance (version 0)
-	if err := InstallGameToInstance(ctx, "release", 0, progress); err != nil {
+	if err := InstallGameToInstance(ctx, "release", 0, env.GetInstanceGameDir("release", 0), progress); err != nil {
 		return fmt.Errorf("failed to install game: %w", err)
 	}
 
@@ -146,7 +152,7 @@ func EnsureInstalledVersion(ctx context.Context, versionType string, progress fu
 	}
 
 	// Install the game to auto-updating latest instance (version 0)
-	if err := InstallGameToInstance(ctx, versionType, 0, progress); err != nil {
+	if err := InstallGameToInstance(ctx, versionType, 0, env.GetInstanceGameDir(versionType, 0), progress); err != nil {
 		return fmt.Errorf("failed to install game: %w", err)
 	}
 
@@ -210,16 +216,19 @@ func EnsureInstalledVersionSpecific(ctx context.Context, versionType string, ver
 	}
 
 	// Install to instance-specific directory
-	if err := InstallGameToInstance(ctx, versionType, version, progress); err != nil {
+	if err := InstallGameToInstance(ctx, versionType, version, env.GetInstanceGameDir(versionType, version), progress); err != nil {
 		return fmt.Errorf("failed to install game: %w", err)
 	}
 
 	return nil
 }
 
-// InstallGameToInstance installs the game to an instance-specific directory
-func InstallGameToInstance(ctx context.Context, versionType string, version int, progressCallback func(stage string, progress float64, message string, currentFile string, speed string, downloaded, total int64)) error {
-	instanceGameDir := env.GetInstanceGameDir(versionType, version)
+// InstallGameToInstance installs the game into targetDir, the instance's own
+// directory (e.g. Instance.Path) rather than the legacy shared
+// (versionType, version) layout, so each instance's files stay under the
+// directory its Instance record actually points at.
+func InstallGameToInstance(ctx context.Context, versionType string, version int, targetDir string, progressCallback func(stage string, progress float64, message string, currentFile string, speed string, downloaded, total int64)) error {
+	instanceGameDir := targetDir
 
 	// Download the patch file
 	pwrPath, err := pwr.DownloadPWR(ctx, versionType, 0, version, progressCallback)
@@ -259,7 +268,7 @@ func InstallGameToInstance(ctx context.Context, versionType string, version int,
 	}
 
 	// Save version marker in instance directory
-	versionFile := filepath.Join(env.GetInstanceDir(versionType, version), "version.txt")
+	versionFile := filepath.Join(targetDir, "version.txt")
 	os.WriteFile(versionFile, []byte(fmt.Sprintf("%d", version)), 0644)
 
 	if progressCallback != nil {
@@ -269,6 +278,149 @@ func InstallGameToInstance(ctx context.Context, versionType string, version int,
 	return nil
 }
 
+// InstallInstance installs or updates a named instance, identified by ID,
+// tracking the in-flight install per instance rather than globally so other
+// instances remain playable while this one installs.
+func InstallInstance(ctx context.Context, instanceID string, progressCallback func(stage string, progress float64, message string, currentFile string, speed string, downloaded, total int64)) error {
+	if err := instance.BeginInstall(instanceID); err != nil {
+		return err
+	}
+	defer instance.EndInstall(instanceID)
+
+	inst, err := instance.Get(instanceID)
+	if err != nil {
+		return err
+	}
+
+	if err := fetchJREAndButler(ctx, progressCallback); err != nil {
+		return err
+	}
+
+	version := inst.Version
+	if version == 0 {
+		result := pwr.FindLatestVersionWithDetails(inst.VersionType)
+		if result.Error != nil {
+			return fmt.Errorf("cannot find %s versions: %w", inst.VersionType, result.Error)
+		}
+		version = result.LatestVersion
+	}
+
+	if inst.DiskURL != "" {
+		return installInstanceToRemoteDisk(ctx, inst, version, progressCallback)
+	}
+
+	if err := InstallGameToInstance(ctx, inst.VersionType, version, inst.Path, progressCallback); err != nil {
+		return fmt.Errorf("failed to install instance %q: %w", inst.Name, err)
+	}
+
+	clientPath := instanceClientPath(inst.Path)
+	var size int64
+	if info, err := os.Stat(clientPath); err == nil {
+		size = info.Size()
+	}
+	patchSHA256, err := hashFile(clientPath)
+	if err != nil {
+		return fmt.Errorf("hashing installed client: %w", err)
+	}
+	if err := instance.WriteVersionRecord(inst.ID, inst.Path, instance.VersionRecord{
+		VersionType:      inst.VersionType,
+		Version:          version,
+		PatchSHA256:      patchSHA256,
+		ClientExecutable: clientPath,
+		SizeBytes:        size,
+	}); err != nil {
+		return fmt.Errorf("recording installed version: %w", err)
+	}
+
+	return nil
+}
+
+// installInstanceToRemoteDisk installs an instance whose DiskURL points at
+// an FTP/SFTP remote instead of the local filesystem: butler still needs a
+// real local FS to apply against, so the patch is downloaded and applied
+// locally, then the resulting Client tree is streamed onto the remote disk.
+// There's no local Path to write version.json/versions.json against for a
+// remote instance, so - unlike the local path above - no version record is
+// written; RepairInstallation-style verification for remote instances isn't
+// supported yet.
+func installInstanceToRemoteDisk(ctx context.Context, inst *instance.Instance, version int, progressCallback func(stage string, progress float64, message string, currentFile string, speed string, downloaded, total int64)) error {
+	remote, err := disk.Open(inst.DiskURL)
+	if err != nil {
+		return fmt.Errorf("opening instance disk %q: %w", inst.DiskURL, err)
+	}
+	defer remote.Close()
+
+	pwrPath, err := pwr.DownloadPWR(ctx, inst.VersionType, 0, version, progressCallback)
+	if err != nil {
+		return fmt.Errorf("failed to download game patch: %w", err)
+	}
+
+	if err := pwr.ApplyPWRToRemoteDisk(ctx, pwrPath, remote, "", progressCallback); err != nil {
+		return fmt.Errorf("failed to install instance %q to remote disk: %w", inst.Name, err)
+	}
+
+	return nil
+}
+
+// hashFile streams a file through SHA-256 and returns the hex digest, so
+// InstallInstance can record a PatchSHA256 that instance.Verify has
+// something real to check the client binary against.
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func instanceClientPath(instanceDir string) string {
+	switch runtime.GOOS {
+	case "darwin":
+		return filepath.Join(instanceDir, "Client", "Hytale.app", "Contents", "MacOS", "HytaleClient")
+	case "windows":
+		return filepath.Join(instanceDir, "Client", "HytaleClient.exe")
+	default:
+		return filepath.Join(instanceDir, "Client", "HytaleClient")
+	}
+}
+
+// fetchJREAndButler downloads the JRE and installs Butler concurrently,
+// since neither depends on the other. A single progressCallback is shared;
+// callers can distinguish the two by the "stage" argument it reports.
+func fetchJREAndButler(ctx context.Context, progress func(stage string, progress float64, msg string, file string, speed string, down, total int64)) error {
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if err := java.DownloadJRE(ctx, progress); err != nil {
+			errs <- fmt.Errorf("failed to download Java Runtime: %w", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if _, err := butler.InstallButler(ctx, progress); err != nil {
+			errs <- fmt.Errorf("failed to install Butler tool: %w", err)
+		}
+	}()
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func getFirstURL(urls []string) string {
 	if len(urls) == 0 {
 		return "none"
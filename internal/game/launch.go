@@ -11,10 +11,106 @@ import (
 	"strings"
 
 	"HyPrism/internal/env"
+	"HyPrism/internal/instance"
+	"HyPrism/internal/java"
 )
 
 // Legacy Launch() removed - use LaunchInstance() instead
 
+// LaunchNamedInstance launches a named Instance, honoring its JavaPath
+// override instead of always using the bundled JRE under baseDir/jre. When
+// JavaPath isn't set, it consults java.Locate() for a usable runtime before
+// falling back to LaunchInstance's bundled-JRE behavior.
+func LaunchNamedInstance(inst *instance.Instance, defaultPlayerName string) error {
+	playerName := inst.PlayerName
+	if playerName == "" {
+		playerName = defaultPlayerName
+	}
+
+	javaPath := inst.JavaPath
+	if javaPath == "" {
+		if runtimes := java.Locate(); len(runtimes) > 0 {
+			javaPath = runtimes[0].Path
+		}
+	}
+
+	var err error
+	if javaPath != "" {
+		err = launchInstanceWithJava(playerName, inst.VersionType, inst.Version, javaPath, inst.JVMArgs)
+	} else {
+		err = LaunchInstance(playerName, inst.VersionType, inst.Version)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := instance.TouchLastPlayed(inst.ID); err != nil {
+		fmt.Printf("warning: failed to record last-played time for %q: %v\n", inst.Name, err)
+	}
+
+	return nil
+}
+
+// launchInstanceWithJava is LaunchInstance but with an explicit java binary
+// instead of the bundled baseDir/jre one. jvmArgs is currently unused here:
+// this exec model launches the native client binary directly (it invokes
+// java itself via --java-exec), so per-instance JVM args only take effect
+// through the internal/launch manifest-driven launch path.
+func launchInstanceWithJava(playerName, branch string, version int, javaPath string, jvmArgs []string) error {
+	baseDir := env.GetDefaultAppDir()
+	gameDir := env.GetInstanceGameDir(branch, version)
+
+	var clientPath string
+	switch runtime.GOOS {
+	case "darwin":
+		clientPath = filepath.Join(gameDir, "Client", "Hytale.app", "Contents", "MacOS", "HytaleClient")
+	case "windows":
+		clientPath = filepath.Join(gameDir, "Client", "HytaleClient.exe")
+	default:
+		clientPath = filepath.Join(gameDir, "Client", "HytaleClient")
+	}
+
+	if _, err := os.Stat(clientPath); err != nil {
+		return fmt.Errorf("game client not found at %s (instance %s v%d not installed): %w", clientPath, branch, version, err)
+	}
+	if _, err := os.Stat(javaPath); err != nil {
+		return fmt.Errorf("java not found at %s: %w", javaPath, err)
+	}
+
+	userDataDir := env.GetInstanceUserDataDir(branch, version)
+	_ = os.MkdirAll(userDataDir, 0755)
+
+	cmd := exec.Command(clientPath,
+		"--app-dir", gameDir,
+		"--user-dir", userDataDir,
+		"--java-exec", javaPath,
+		"--auth-mode", "offline",
+		"--uuid", OfflineUUID(playerName).String(),
+		"--name", playerName,
+	)
+	cmd.Dir = baseDir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	if runtime.GOOS == "windows" {
+		cmd.SysProcAttr = getWindowsSysProcAttr()
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start game: %w", err)
+	}
+
+	gameProcess = cmd.Process
+	gameRunning = true
+	go func() {
+		cmd.Wait()
+		gameProcess = nil
+		gameRunning = false
+	}()
+
+	return nil
+}
+
 // LaunchInstance launches a specific branch/version instance
 func LaunchInstance(playerName string, branch string, version int) error {
 	baseDir := env.GetDefaultAppDir()
@@ -0,0 +1,132 @@
+// Package healthcheck provides a small registry of named, independently
+// runnable checks (connectivity, installed game state, dependency
+// availability, ...) along with an HTTP server exposing them in a format
+// dedicated-server hosts can script or monitor against.
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// Result is one check's outcome.
+type Result struct {
+	Name      string        `json:"name"`
+	OK        bool          `json:"ok"`
+	Critical  bool          `json:"critical"`
+	Message   string        `json:"message,omitempty"`
+	Detail    any           `json:"detail,omitempty"`
+	Duration  time.Duration `json:"durationMs"`
+	CheckedAt time.Time     `json:"checkedAt"`
+}
+
+// MarshalJSON encodes Duration in milliseconds, matching its durationMs key.
+func (r Result) MarshalJSON() ([]byte, error) {
+	type plain Result
+	return json.Marshal(struct {
+		plain
+		Duration float64 `json:"durationMs"`
+	}{plain(r), float64(r.Duration) / float64(time.Millisecond)})
+}
+
+// Healthcheck is one independently runnable diagnostic. Critical checks
+// gate readiness (GET /readyz returns 503 if any fail); non-critical
+// checks are still reported by /healthz and /metrics but don't affect
+// the status code.
+type Healthcheck interface {
+	Name() string
+	Critical() bool
+	Check(ctx context.Context) Result
+}
+
+// Registry holds the set of registered checks and caches each one's most
+// recent result for /metrics, which shouldn't force a re-run of every
+// check on every scrape.
+type Registry struct {
+	mu     sync.RWMutex
+	checks []Healthcheck
+	last   map[string]Result
+}
+
+// NewRegistry returns an empty registry ready for Register calls.
+func NewRegistry() *Registry {
+	return &Registry{last: make(map[string]Result)}
+}
+
+// Register adds a check to the registry. Not safe to call concurrently
+// with RunAll.
+func (r *Registry) Register(c Healthcheck) {
+	r.checks = append(r.checks, c)
+}
+
+// RunAll runs every registered check, caches the results, and returns
+// them in registration order.
+func (r *Registry) RunAll(ctx context.Context) []Result {
+	results := make([]Result, len(r.checks))
+	for i, c := range r.checks {
+		start := time.Now()
+		res := c.Check(ctx)
+		res.Name = c.Name()
+		res.Critical = c.Critical()
+		res.Duration = time.Since(start)
+		res.CheckedAt = start
+		results[i] = res
+	}
+
+	r.mu.Lock()
+	for _, res := range results {
+		r.last[res.Name] = res
+	}
+	r.mu.Unlock()
+
+	return results
+}
+
+// RunCritical runs only the critical checks, for /readyz.
+func (r *Registry) RunCritical(ctx context.Context) []Result {
+	var results []Result
+	for _, c := range r.checks {
+		if !c.Critical() {
+			continue
+		}
+		start := time.Now()
+		res := c.Check(ctx)
+		res.Name = c.Name()
+		res.Critical = true
+		res.Duration = time.Since(start)
+		res.CheckedAt = start
+		results = append(results, res)
+
+		r.mu.Lock()
+		r.last[res.Name] = res
+		r.mu.Unlock()
+	}
+	return results
+}
+
+// Last returns the cached result of each check's most recent run, without
+// re-running anything. Checks that have never run are omitted.
+func (r *Registry) Last() []Result {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	results := make([]Result, 0, len(r.checks))
+	for _, c := range r.checks {
+		if res, ok := r.last[c.Name()]; ok {
+			results = append(results, res)
+		}
+	}
+	return results
+}
+
+// AllOK reports whether every result in results passed.
+func AllOK(results []Result) bool {
+	for _, res := range results {
+		if !res.OK {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,121 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// Server exposes a Registry over HTTP for headless/dedicated-server hosts
+// to script or monitor against. It is opt-in: nothing in this package
+// starts a listener on its own.
+type Server struct {
+	registry *Registry
+	httpSrv  *http.Server
+}
+
+// NewServer builds a Server bound to addr (e.g. "127.0.0.1:9090"). Call
+// Start to begin listening.
+func NewServer(registry *Registry, addr string) *Server {
+	s := &Server{registry: registry}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/livez", s.handleLivez)
+	mux.HandleFunc("/readyz", s.handleReadyz)
+	mux.HandleFunc("/healthz", s.handleHealthz)
+	mux.HandleFunc("/metrics", s.handleMetrics)
+
+	s.httpSrv = &http.Server{Addr: addr, Handler: mux}
+	return s
+}
+
+// Start begins listening in the background. It returns once the listener
+// is bound, so callers know immediately whether the port was available.
+func (s *Server) Start() error {
+	ln, err := net.Listen("tcp", s.httpSrv.Addr)
+	if err != nil {
+		return fmt.Errorf("binding healthcheck server: %w", err)
+	}
+
+	go func() {
+		_ = s.httpSrv.Serve(ln)
+	}()
+	return nil
+}
+
+// Stop gracefully shuts down the server.
+func (s *Server) Stop(ctx context.Context) error {
+	return s.httpSrv.Shutdown(ctx)
+}
+
+// handleLivez reports process liveness only - it never runs checks, so it
+// stays cheap and fast even if a downstream check is hanging.
+func (s *Server) handleLivez(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
+
+// handleReadyz runs the critical checks and returns 503 if any fail.
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	results := s.registry.RunCritical(r.Context())
+	writeResults(w, results)
+}
+
+// handleHealthz runs every registered check and returns 503 if any
+// critical one fails.
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	results := s.registry.RunAll(r.Context())
+	writeResults(w, results)
+}
+
+func writeResults(w http.ResponseWriter, results []Result) {
+	status := http.StatusOK
+	for _, res := range results {
+		if res.Critical && !res.OK {
+			status = http.StatusServiceUnavailable
+			break
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"status": status == http.StatusOK,
+		"checks": results,
+	})
+}
+
+// handleMetrics emits Prometheus text-exposition-format gauges from the registry's cached last-run results. It never triggers a fresh
+// run, so scraping is always cheap.
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	results := s.registry.Last()
+
+	fmt.Fprintln(w, "# HELP hyprism_healthcheck_up 1 if the check last passed, 0 otherwise")
+	fmt.Fprintln(w, "# TYPE hyprism_healthcheck_up gauge")
+	for _, res := range results {
+		fmt.Fprintf(w, "hyprism_healthcheck_up{check=%q} %s\n", res.Name, boolMetric(res.OK))
+	}
+
+	fmt.Fprintln(w, "# HELP hyprism_healthcheck_duration_seconds Duration of the check's last run")
+	fmt.Fprintln(w, "# TYPE hyprism_healthcheck_duration_seconds gauge")
+	for _, res := range results {
+		fmt.Fprintf(w, "hyprism_healthcheck_duration_seconds{check=%q} %f\n", res.Name, res.Duration.Seconds())
+	}
+
+	fmt.Fprintln(w, "# HELP hyprism_healthcheck_last_run_timestamp_seconds Unix timestamp of the check's last run")
+	fmt.Fprintln(w, "# TYPE hyprism_healthcheck_last_run_timestamp_seconds gauge")
+	for _, res := range results {
+		fmt.Fprintf(w, "hyprism_healthcheck_last_run_timestamp_seconds{check=%q} %d\n", res.Name, res.CheckedAt.Unix())
+	}
+}
+
+func boolMetric(ok bool) string {
+	if ok {
+		return "1"
+	}
+	return "0"
+}
@@ -0,0 +1,347 @@
+package instance
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"HyPrism/internal/env"
+)
+
+// installMu guards the per-instance installing set below. It replaces the
+// single global isInstalling flag that used to live in internal/game, which
+// made it impossible to install one instance while playing another.
+var (
+	installMu  sync.Mutex
+	installing = map[string]bool{}
+)
+
+// BeginInstall marks an instance as installing. It returns an error if the
+// instance is already being installed.
+func BeginInstall(id string) error {
+	installMu.Lock()
+	defer installMu.Unlock()
+	if installing[id] {
+		return fmt.Errorf("instance %q is already installing", id)
+	}
+	installing[id] = true
+	return nil
+}
+
+// EndInstall clears the installing flag for an instance.
+func EndInstall(id string) {
+	installMu.Lock()
+	defer installMu.Unlock()
+	delete(installing, id)
+}
+
+// IsInstalling reports whether an instance currently has an install in flight.
+func IsInstalling(id string) bool {
+	installMu.Lock()
+	defer installMu.Unlock()
+	return installing[id]
+}
+
+// List returns every configured instance.
+func List() ([]Instance, error) {
+	m, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	return m.Instances, nil
+}
+
+// Create registers a new instance at path (or the default instance path for
+// versionType/version when path is empty) and returns it.
+func Create(name, versionType string, version int, path string) (*Instance, error) {
+	m, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("instance name cannot be empty")
+	}
+	for _, existing := range m.Instances {
+		if strings.EqualFold(existing.Name, name) {
+			return nil, fmt.Errorf("an instance named %q already exists", name)
+		}
+	}
+
+	path = strings.TrimSpace(path)
+	if path == "" {
+		path = env.GetInstanceDir(versionType, version)
+	}
+
+	inst := Instance{
+		ID:          newID(),
+		Name:        name,
+		Path:        path,
+		VersionType: versionType,
+		Version:     version,
+		CreatedAt:   time.Now(),
+	}
+
+	m.Instances = append(m.Instances, inst)
+	if m.Selected == "" {
+		m.Selected = inst.ID
+	}
+
+	if err := Save(m); err != nil {
+		return nil, err
+	}
+	return &inst, nil
+}
+
+// Delete removes an instance from the manifest and, if removeFiles is true,
+// deletes its on-disk directory.
+func Delete(id string, removeFiles bool) error {
+	m, err := Load()
+	if err != nil {
+		return err
+	}
+
+	inst, err := m.Find(id)
+	if err != nil {
+		return err
+	}
+	path := inst.Path
+
+	filtered := m.Instances[:0]
+	for _, existing := range m.Instances {
+		if existing.ID != id {
+			filtered = append(filtered, existing)
+		}
+	}
+	m.Instances = filtered
+
+	if m.Selected == id {
+		m.Selected = ""
+		if len(m.Instances) > 0 {
+			m.Selected = m.Instances[0].ID
+		}
+	}
+
+	if err := Save(m); err != nil {
+		return err
+	}
+
+	if removeFiles && path != "" {
+		if err := os.RemoveAll(path); err != nil {
+			return fmt.Errorf("removing instance directory: %w", err)
+		}
+	}
+	return nil
+}
+
+// Rename changes an instance's display name.
+func Rename(id, name string) error {
+	m, err := Load()
+	if err != nil {
+		return err
+	}
+	inst, err := m.Find(id)
+	if err != nil {
+		return err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("instance name cannot be empty")
+	}
+	inst.Name = name
+	return Save(m)
+}
+
+// Select marks an instance as the active one. It refuses to select an
+// instance whose Path no longer exists on disk, so the launcher doesn't
+// silently try to launch a moved/deleted install.
+func Select(id string) error {
+	m, err := Load()
+	if err != nil {
+		return err
+	}
+	inst, err := m.Find(id)
+	if err != nil {
+		return err
+	}
+	if inst.Path != "" {
+		if _, err := os.Stat(inst.Path); err != nil {
+			return fmt.Errorf("instance %q: path %s is not accessible: %w", inst.Name, inst.Path, err)
+		}
+	}
+	m.Selected = id
+	return Save(m)
+}
+
+// TouchLastPlayed records that an instance was just launched.
+func TouchLastPlayed(id string) error {
+	m, err := Load()
+	if err != nil {
+		return err
+	}
+	inst, err := m.Find(id)
+	if err != nil {
+		return err
+	}
+	inst.LastPlayedAt = time.Now()
+	return Save(m)
+}
+
+// Get returns a single instance by ID.
+func Get(id string) (*Instance, error) {
+	m, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	return m.Find(id)
+}
+
+// Add registers an already-built Instance value as-is (unlike Create, which
+// generates the ID/name validation for a brand new instance). It exists for
+// callers migrating an existing installation record wholesale, e.g. an
+// import from another launcher.
+func Add(inst Instance) error {
+	m, err := Load()
+	if err != nil {
+		return err
+	}
+	if inst.ID == "" {
+		inst.ID = newID()
+	}
+	if inst.CreatedAt.IsZero() {
+		inst.CreatedAt = time.Now()
+	}
+	m.Instances = append(m.Instances, inst)
+	if m.Selected == "" {
+		m.Selected = inst.ID
+	}
+	return Save(m)
+}
+
+// Remove is an alias for Delete(id, false) - it drops the manifest entry
+// without touching the instance's files.
+func Remove(id string) error {
+	return Delete(id, false)
+}
+
+// Validate checks that an instance's client binary still exists on disk,
+// so the UI can warn on a renamed/missing/moved instance before launch.
+func Validate(id string) error {
+	inst, err := Get(id)
+	if err != nil {
+		return err
+	}
+	clientPath := clientExecutablePath(inst.Path)
+	if _, err := os.Stat(clientPath); err != nil {
+		return fmt.Errorf("instance %q: client not found at %s: %w", inst.Name, clientPath, err)
+	}
+	return nil
+}
+
+// Duplicate copies an existing instance's on-disk directory into a sibling
+// folder and registers it as a new instance with the same version/profile
+// settings, so users can branch off a working install (e.g. to try a mod)
+// without re-downloading it.
+func Duplicate(id, newName string) (*Instance, error) {
+	m, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := m.Find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		return nil, fmt.Errorf("instance name cannot be empty")
+	}
+	for _, existing := range m.Instances {
+		if strings.EqualFold(existing.Name, newName) {
+			return nil, fmt.Errorf("an instance named %q already exists", newName)
+		}
+	}
+
+	newPath := ""
+	if src.Path != "" {
+		newPath = filepath.Join(filepath.Dir(src.Path), sanitizeDirName(newName))
+		if _, err := os.Stat(newPath); err == nil {
+			return nil, fmt.Errorf("destination path %s already exists", newPath)
+		}
+		if err := copyTree(src.Path, newPath); err != nil {
+			return nil, fmt.Errorf("copying instance files: %w", err)
+		}
+	}
+
+	dup := *src
+	dup.ID = newID()
+	dup.Name = newName
+	dup.Path = newPath
+	dup.CreatedAt = time.Now()
+	dup.LastPlayedAt = time.Time{}
+
+	m.Instances = append(m.Instances, dup)
+	if err := Save(m); err != nil {
+		return nil, err
+	}
+	return &dup, nil
+}
+
+func sanitizeDirName(name string) string {
+	replacer := strings.NewReplacer("/", "-", "\\", "-", ":", "-")
+	return replacer.Replace(name)
+}
+
+func copyTree(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, info.Mode())
+		}
+		return copyFile(path, target, info.Mode())
+	})
+}
+
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
+
+// GetSelected returns the currently selected instance.
+func GetSelected() (*Instance, error) {
+	m, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	return m.SelectedInstance()
+}
@@ -0,0 +1,190 @@
+// Package instance manages named game installations ("instances"), each
+// pinned to a version type/version and living at its own path. It replaces
+// the old (versionType, version) pair that env.GetInstanceGameDir used to
+// key everything on with a persisted manifest so users can have more than
+// one named install (e.g. "Vanilla", "Modded", "Pre-release Testing").
+package instance
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"HyPrism/internal/env"
+)
+
+// manifestVersion is the schema version of instances.json, bumped whenever
+// the on-disk shape changes so Load can migrate forward.
+const manifestVersion = 1
+
+const manifestFileName = "instances.json"
+
+// Instance is a single named installation.
+type Instance struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Path        string   `json:"path"`
+	VersionType string   `json:"versionType"`
+	Version     int      `json:"version"`
+	Profile     string   `json:"profile,omitempty"`
+	Vanilla     bool     `json:"vanilla"`
+	// JavaPath overrides the bundled JRE for this instance, when set.
+	JavaPath string `json:"javaPath,omitempty"`
+	// JVMArgs are extra JVM arguments appended for this instance, e.g. "-Xmx4G".
+	JVMArgs []string `json:"jvmArgs,omitempty"`
+	// PlayerName overrides the global nickname for this instance, when set.
+	PlayerName string `json:"playerName,omitempty"`
+	// DiskURL points at where this instance's files actually live (e.g.
+	// "file:///...", "sftp://user@host/path", "ftp://..."), resolved via
+	// internal/disk.Open. Empty means Path on the local filesystem, same as
+	// before disk.Disk existed.
+	DiskURL      string    `json:"diskURL,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+	LastPlayedAt time.Time `json:"lastPlayedAt,omitzero"`
+}
+
+// Manifest is the on-disk instances.json shape.
+type Manifest struct {
+	Version   int        `json:"version"`
+	Selected  string     `json:"selected"`
+	Instances []Instance `json:"instances"`
+}
+
+var (
+	mu    sync.Mutex
+	cache *Manifest
+)
+
+func manifestPath() string {
+	return filepath.Join(env.GetDefaultAppDir(), manifestFileName)
+}
+
+// Load reads instances.json, migrating the legacy release-0/pre-release-0
+// layout into it on first run. The result is cached in memory; callers that
+// mutate the manifest must go through Save to persist and refresh the cache.
+func Load() (*Manifest, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if cache != nil {
+		return cache, nil
+	}
+
+	data, err := os.ReadFile(manifestPath())
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("reading instance manifest: %w", err)
+		}
+		m := migrateLegacyLayout()
+		if err := save(m); err != nil {
+			return nil, err
+		}
+		cache = m
+		return cache, nil
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parsing instance manifest: %w", err)
+	}
+
+	if m.Version < manifestVersion {
+		m.Version = manifestVersion
+		if err := save(&m); err != nil {
+			return nil, err
+		}
+	}
+
+	cache = &m
+	return cache, nil
+}
+
+// migrateLegacyLayout builds a starter manifest from the pre-existing
+// release-0 / pre-release-0 instance folders, if any are present on disk.
+func migrateLegacyLayout() *Manifest {
+	m := &Manifest{Version: manifestVersion}
+
+	candidates := []struct {
+		name        string
+		versionType string
+	}{
+		{"Release", "release"},
+		{"Pre-release", "pre-release"},
+	}
+
+	for _, c := range candidates {
+		dir := env.GetInstanceGameDir(c.versionType, 0)
+		if _, err := os.Stat(dir); err != nil {
+			continue
+		}
+
+		id := newID()
+		m.Instances = append(m.Instances, Instance{
+			ID:          id,
+			Name:        c.name,
+			Path:        env.GetInstanceDir(c.versionType, 0),
+			VersionType: c.versionType,
+			Version:     0,
+			Vanilla:     true,
+			CreatedAt:   time.Now(),
+		})
+		if m.Selected == "" {
+			m.Selected = id
+		}
+	}
+
+	return m
+}
+
+// Save persists the manifest and refreshes the in-memory cache.
+func Save(m *Manifest) error {
+	mu.Lock()
+	defer mu.Unlock()
+	return save(m)
+}
+
+func save(m *Manifest) error {
+	if err := os.MkdirAll(filepath.Dir(manifestPath()), 0755); err != nil {
+		return fmt.Errorf("creating app dir: %w", err)
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding instance manifest: %w", err)
+	}
+
+	if err := os.WriteFile(manifestPath(), data, 0644); err != nil {
+		return fmt.Errorf("writing instance manifest: %w", err)
+	}
+
+	cache = m
+	return nil
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return fmt.Sprintf("%x", b)
+}
+
+// Find returns the instance with the given ID, or an error if none matches.
+func (m *Manifest) Find(id string) (*Instance, error) {
+	for i := range m.Instances {
+		if m.Instances[i].ID == id {
+			return &m.Instances[i], nil
+		}
+	}
+	return nil, fmt.Errorf("instance %q not found", id)
+}
+
+// SelectedInstance returns the currently selected instance, if any.
+func (m *Manifest) SelectedInstance() (*Instance, error) {
+	if m.Selected == "" {
+		return nil, fmt.Errorf("no instance selected")
+	}
+	return m.Find(m.Selected)
+}
@@ -0,0 +1,224 @@
+package instance
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"HyPrism/internal/env"
+)
+
+const versionSchemaVersion = 1
+
+// VersionRecord describes an installed instance's version in detail. It is
+// written as version.json inside the instance directory, replacing the
+// plain version.txt marker, and mirrored into the global versions.json
+// registry so the app can answer "what's installed where" without walking
+// every instance directory on every call.
+type VersionRecord struct {
+	SchemaVersion    int       `json:"schemaVersion"`
+	VersionType      string    `json:"versionType"`
+	Version          int       `json:"version"`
+	PatchSHA256      string    `json:"patchSHA256,omitempty"`
+	InstalledAt      time.Time `json:"installedAt"`
+	ButlerVersion    string    `json:"butlerVersion,omitempty"`
+	ClientExecutable string    `json:"clientExecutable"`
+	SizeBytes        int64     `json:"sizeBytes"`
+	NeedsVerify      bool      `json:"needsVerify,omitempty"`
+}
+
+type globalVersions struct {
+	SchemaVersion int                      `json:"schemaVersion"`
+	Instances     map[string]VersionRecord `json:"instances"`
+}
+
+func instanceVersionPath(instanceDir string) string {
+	return filepath.Join(instanceDir, "version.json")
+}
+
+func globalVersionsPath() string {
+	return filepath.Join(env.GetDefaultAppDir(), "versions.json")
+}
+
+// WriteVersionRecord writes version.json for an instance and updates the
+// global registry to match.
+func WriteVersionRecord(instanceID, instanceDir string, rec VersionRecord) error {
+	rec.SchemaVersion = versionSchemaVersion
+	if rec.InstalledAt.IsZero() {
+		rec.InstalledAt = time.Now()
+	}
+
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding version record: %w", err)
+	}
+	if err := os.WriteFile(instanceVersionPath(instanceDir), data, 0644); err != nil {
+		return fmt.Errorf("writing version.json: %w", err)
+	}
+
+	gv, err := loadGlobalVersions()
+	if err != nil {
+		gv = &globalVersions{SchemaVersion: versionSchemaVersion, Instances: map[string]VersionRecord{}}
+	}
+	gv.Instances[instanceID] = rec
+	return saveGlobalVersions(gv)
+}
+
+// ReadVersionRecord reads version.json for an instance directly off disk.
+func ReadVersionRecord(instanceDir string) (*VersionRecord, error) {
+	data, err := os.ReadFile(instanceVersionPath(instanceDir))
+	if err != nil {
+		return nil, err
+	}
+	var rec VersionRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("parsing version.json: %w", err)
+	}
+	return &rec, nil
+}
+
+// loadGlobalVersions reads versions.json, self-healing via
+// RebuildGlobalVersions whenever the registry is missing or fails to
+// decode, the same way manifest.go's Load falls back to
+// migrateLegacyLayout on a missing instances.json.
+func loadGlobalVersions() (*globalVersions, error) {
+	data, err := os.ReadFile(globalVersionsPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return RebuildGlobalVersions()
+		}
+		return nil, err
+	}
+	var gv globalVersions
+	if err := json.Unmarshal(data, &gv); err != nil {
+		return RebuildGlobalVersions()
+	}
+	return &gv, nil
+}
+
+func saveGlobalVersions(gv *globalVersions) error {
+	data, err := json.MarshalIndent(gv, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding versions registry: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(globalVersionsPath()), 0755); err != nil {
+		return fmt.Errorf("creating app dir: %w", err)
+	}
+	return os.WriteFile(globalVersionsPath(), data, 0644)
+}
+
+// RebuildGlobalVersions re-derives versions.json by walking every instance
+// directory and reading (or, as a best effort, reconstructing) its
+// version.json. loadGlobalVersions calls this automatically whenever the
+// global registry is missing or fails to decode.
+func RebuildGlobalVersions() (*globalVersions, error) {
+	m, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	gv := &globalVersions{SchemaVersion: versionSchemaVersion, Instances: map[string]VersionRecord{}}
+
+	for _, inst := range m.Instances {
+		if rec, err := ReadVersionRecord(inst.Path); err == nil {
+			gv.Instances[inst.ID] = *rec
+			continue
+		}
+
+		// No per-instance version.json, but a client binary exists: produce
+		// a best-effort record so the UI has something to show, flagged for
+		// a user-initiated re-verify rather than trusted outright.
+		if clientPath := clientExecutablePath(inst.Path); clientPath != "" {
+			if info, err := os.Stat(clientPath); err == nil {
+				gv.Instances[inst.ID] = VersionRecord{
+					SchemaVersion:    versionSchemaVersion,
+					VersionType:      inst.VersionType,
+					Version:          inst.Version,
+					InstalledAt:      info.ModTime(),
+					ClientExecutable: clientPath,
+					SizeBytes:        info.Size(),
+					NeedsVerify:      true,
+				}
+			}
+		}
+	}
+
+	if err := saveGlobalVersions(gv); err != nil {
+		return nil, err
+	}
+	return gv, nil
+}
+
+func clientExecutablePath(instanceDir string) string {
+	switch runtime.GOOS {
+	case "darwin":
+		return filepath.Join(instanceDir, "Client", "Hytale.app", "Contents", "MacOS", "HytaleClient")
+	case "windows":
+		return filepath.Join(instanceDir, "Client", "HytaleClient.exe")
+	default:
+		return filepath.Join(instanceDir, "Client", "HytaleClient")
+	}
+}
+
+// VerifyReport is the result of re-checking an installed instance's files
+// against their recorded hash.
+type VerifyReport struct {
+	OK       bool     `json:"ok"`
+	Checked  int      `json:"checked"`
+	Mismatch []string `json:"mismatch,omitempty"`
+}
+
+// Verify re-hashes an instance's client executable against the SHA-256
+// recorded in PatchSHA256 at install time and reports a mismatch. Only the
+// client executable is checked; other files under Client are not.
+// RepairInstallation does not consult this report.
+func Verify(instanceID string) (VerifyReport, error) {
+	inst, err := Get(instanceID)
+	if err != nil {
+		return VerifyReport{}, err
+	}
+
+	rec, err := ReadVersionRecord(inst.Path)
+	if err != nil {
+		return VerifyReport{}, fmt.Errorf("no version record for instance %q: %w", inst.Name, err)
+	}
+
+	report := VerifyReport{OK: true, Checked: 1}
+
+	if rec.PatchSHA256 == "" {
+		return report, nil
+	}
+
+	clientPath := clientExecutablePath(inst.Path)
+	f, err := os.Open(clientPath)
+	if err != nil {
+		report.OK = false
+		report.Mismatch = append(report.Mismatch, clientPath)
+		return report, nil
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	buf := make([]byte, 1024*1024)
+	for {
+		n, rerr := f.Read(buf)
+		if n > 0 {
+			h.Write(buf[:n])
+		}
+		if rerr != nil {
+			break
+		}
+	}
+
+	if hex.EncodeToString(h.Sum(nil)) != rec.PatchSHA256 {
+		report.OK = false
+		report.Mismatch = append(report.Mismatch, clientPath)
+	}
+
+	return report, nil
+}
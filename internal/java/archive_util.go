@@ -0,0 +1,73 @@
+package java
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// copyAndVerify streams src into dst while hashing it, returning an error if
+// the final digest doesn't match expectedSHA256 (when non-empty).
+func copyAndVerify(dst io.Writer, src io.Reader, expectedSHA256 string) (int64, error) {
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(dst, h), src)
+	if err != nil {
+		return n, err
+	}
+
+	if expectedSHA256 != "" {
+		got := hex.EncodeToString(h.Sum(nil))
+		if got != expectedSHA256 {
+			return n, fmt.Errorf("JRE archive checksum mismatch: expected %s, got %s", expectedSHA256, got)
+		}
+	}
+	return n, nil
+}
+
+func ioCopy(dst io.Writer, src io.Reader) (int64, error) {
+	return io.Copy(dst, src)
+}
+
+// untar extracts a tar stream into destDir, stripping the archive's single
+// top-level directory (Adoptium ships e.g. "jdk-21.0.1+12-jre/bin/java").
+func untar(r io.Reader, destDir string) error {
+	tr := tar.NewReader(r)
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(destDir, stripTopLevelDir(hdr.Name))
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			out, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(hdr.Mode))
+			if err != nil {
+				return err
+			}
+			_, copyErr := io.Copy(out, tr)
+			out.Close()
+			if copyErr != nil {
+				return copyErr
+			}
+		case tar.TypeSymlink:
+			_ = os.Symlink(hdr.Linkname, target)
+		}
+	}
+}
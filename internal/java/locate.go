@@ -0,0 +1,117 @@
+package java
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"HyPrism/internal/env"
+)
+
+// Runtime describes one candidate JRE/JDK found on the host.
+type Runtime struct {
+	Path         string
+	MajorVersion int
+	Vendor       string
+	Arch         string
+}
+
+// Locate finds candidate Java runtimes by checking JAVA_HOME, PATH, common
+// per-OS install locations, and the bundled baseDir/jre, instead of always
+// assuming the bundled JRE is the only one that exists. Each candidate is
+// probed by actually running it, so stale/broken installs are skipped
+// rather than reported as usable.
+func Locate() []Runtime {
+	seen := map[string]bool{}
+	var runtimes []Runtime
+
+	for _, candidate := range candidatePaths() {
+		if candidate == "" || seen[candidate] {
+			continue
+		}
+		seen[candidate] = true
+
+		if rt, ok := probe(candidate); ok {
+			runtimes = append(runtimes, rt)
+		}
+	}
+
+	return runtimes
+}
+
+func candidatePaths() []string {
+	var paths []string
+
+	if home := os.Getenv("JAVA_HOME"); home != "" {
+		paths = append(paths, javaBinIn(home))
+	}
+
+	if exe, err := exec.LookPath(javaExecutableName()); err == nil {
+		paths = append(paths, exe)
+	}
+
+	paths = append(paths, platformInstallLocations()...)
+	paths = append(paths, javaBinIn(filepath.Join(env.GetDefaultAppDir(), "jre")))
+
+	return paths
+}
+
+func javaExecutableName() string {
+	if runtime.GOOS == "windows" {
+		return "java.exe"
+	}
+	return "java"
+}
+
+func javaBinIn(home string) string {
+	return filepath.Join(home, "bin", javaExecutableName())
+}
+
+// probe runs "java -XshowSettings:properties -version" and parses the
+// vendor/version/arch properties out of its (stderr) output.
+func probe(javaPath string) (Runtime, bool) {
+	if _, err := os.Stat(javaPath); err != nil {
+		return Runtime{}, false
+	}
+
+	cmd := exec.Command(javaPath, "-XshowSettings:properties", "-version")
+	output, _ := cmd.CombinedOutput()
+	text := string(output)
+
+	rt := Runtime{Path: javaPath}
+
+	if m := versionRegexp.FindStringSubmatch(text); m != nil {
+		rt.MajorVersion = parseMajorVersion(m[1])
+	}
+	if m := vendorRegexp.FindStringSubmatch(text); m != nil {
+		rt.Vendor = strings.TrimSpace(m[1])
+	}
+	if m := archRegexp.FindStringSubmatch(text); m != nil {
+		rt.Arch = strings.TrimSpace(m[1])
+	}
+
+	return rt, rt.MajorVersion > 0
+}
+
+var (
+	versionRegexp = regexp.MustCompile(`java\.version = (\S+)`)
+	vendorRegexp  = regexp.MustCompile(`java\.vendor = (.+)`)
+	archRegexp    = regexp.MustCompile(`os\.arch = (.+)`)
+)
+
+// parseMajorVersion turns "1.8.0_392" or "21.0.1" into 8 or 21.
+func parseMajorVersion(v string) int {
+	v = strings.TrimPrefix(v, "1.")
+	parts := strings.SplitN(v, ".", 2)
+	major := 0
+	for _, c := range parts[0] {
+		if c < '0' || c > '9' {
+			break
+		}
+		major = major*10 + int(c-'0')
+	}
+	return major
+}
@@ -0,0 +1,34 @@
+//go:build !windows
+
+package java
+
+import (
+	"path/filepath"
+	"runtime"
+)
+
+// platformInstallLocations globs the common system JRE/JDK install
+// directories for Linux and macOS.
+func platformInstallLocations() []string {
+	var roots []string
+
+	if runtime.GOOS == "darwin" {
+		roots = append(roots, globBin("/Library/Java/JavaVirtualMachines/*/Contents/Home")...)
+	} else {
+		roots = append(roots, globBin("/usr/lib/jvm/*")...)
+	}
+
+	return roots
+}
+
+func globBin(pattern string) []string {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, m := range matches {
+		paths = append(paths, javaBinIn(m))
+	}
+	return paths
+}
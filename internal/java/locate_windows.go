@@ -0,0 +1,19 @@
+//go:build windows
+
+package java
+
+import "path/filepath"
+
+// platformInstallLocations globs the common JRE/JDK install directories on
+// Windows, e.g. Eclipse Temurin/Adoptium's default install path.
+func platformInstallLocations() []string {
+	matches, err := filepath.Glob(`C:\Program Files\Eclipse Adoptium\*`)
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, m := range matches {
+		paths = append(paths, javaBinIn(m))
+	}
+	return paths
+}
@@ -0,0 +1,213 @@
+package java
+
+import (
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"HyPrism/internal/env"
+)
+
+// adoptiumAPI returns the Eclipse Temurin binary listing for a given major
+// version, OS and architecture. See https://api.adoptium.net.
+const adoptiumAPI = "https://api.adoptium.net/v3/assets/latest/%d/hotspot?os=%s&architecture=%s&image_type=jre"
+
+type adoptiumAsset struct {
+	Binary struct {
+		Package struct {
+			Link     string `json:"link"`
+			Checksum string `json:"checksum"`
+		} `json:"package"`
+	} `json:"binary"`
+}
+
+// runtimeCacheDir returns the per-major-version cache directory instances
+// pinned to that major version share.
+func runtimeCacheDir(major int) string {
+	return filepath.Join(env.GetDefaultAppDir(), "jre-runtimes", fmt.Sprintf("%d", major))
+}
+
+// EnsureRuntime downloads (if not already cached) a Temurin JRE for the
+// given major Java version, verifying it against the SHA-256 the Adoptium
+// API publishes, and returns the path to its java executable. This lets
+// different instances pin to different Java majors instead of all sharing
+// a single bundled JRE.
+func EnsureRuntime(major int) (string, error) {
+	dir := runtimeCacheDir(major)
+	javaPath := javaBinIn(dir)
+
+	if _, err := os.Stat(javaPath); err == nil {
+		return javaPath, nil
+	}
+
+	asset, err := fetchAdoptiumAsset(major)
+	if err != nil {
+		return "", fmt.Errorf("looking up Temurin %d JRE: %w", major, err)
+	}
+
+	archivePath, err := downloadRuntimeArchive(asset)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(archivePath)
+
+	if err := extractArchive(archivePath, dir); err != nil {
+		return "", fmt.Errorf("extracting JRE archive: %w", err)
+	}
+
+	if _, err := os.Stat(javaPath); err != nil {
+		return "", fmt.Errorf("JRE archive did not contain expected binary at %s", javaPath)
+	}
+
+	return javaPath, nil
+}
+
+func fetchAdoptiumAsset(major int) (*adoptiumAsset, error) {
+	url := fmt.Sprintf(adoptiumAPI, major, adoptiumOS(), adoptiumArch())
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Adoptium API returned HTTP %d", resp.StatusCode)
+	}
+
+	var assets []adoptiumAsset
+	if err := json.NewDecoder(resp.Body).Decode(&assets); err != nil {
+		return nil, fmt.Errorf("parsing Adoptium response: %w", err)
+	}
+	if len(assets) == 0 {
+		return nil, fmt.Errorf("no Temurin JRE published for major %d / %s / %s", major, adoptiumOS(), adoptiumArch())
+	}
+	return &assets[0], nil
+}
+
+func adoptiumOS() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return "mac"
+	default:
+		return runtime.GOOS
+	}
+}
+
+func adoptiumArch() string {
+	switch runtime.GOARCH {
+	case "amd64":
+		return "x64"
+	default:
+		return runtime.GOARCH
+	}
+}
+
+func downloadRuntimeArchive(asset *adoptiumAsset) (string, error) {
+	resp, err := http.Get(asset.Binary.Package.Link)
+	if err != nil {
+		return "", fmt.Errorf("downloading JRE archive: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("downloading JRE archive: HTTP %d", resp.StatusCode)
+	}
+
+	ext := ".tar.gz"
+	if runtime.GOOS == "windows" {
+		ext = ".zip"
+	}
+
+	tmp, err := os.CreateTemp("", "hyprism-jre-*"+ext)
+	if err != nil {
+		return "", err
+	}
+	defer tmp.Close()
+
+	if _, err := copyAndVerify(tmp, resp.Body, asset.Binary.Package.Checksum); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+
+	return tmp.Name(), nil
+}
+
+func extractArchive(archivePath, destDir string) error {
+	if filepath.Ext(archivePath) == ".zip" {
+		return extractZip(archivePath, destDir)
+	}
+	return extractTarGz(archivePath, destDir)
+}
+
+func extractZip(archivePath, destDir string) error {
+	r, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		target := filepath.Join(destDir, stripTopLevelDir(f.Name))
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, f.Mode())
+		if err != nil {
+			rc.Close()
+			return err
+		}
+		_, copyErr := ioCopy(out, rc)
+		rc.Close()
+		out.Close()
+		if copyErr != nil {
+			return copyErr
+		}
+	}
+	return nil
+}
+
+func extractTarGz(archivePath, destDir string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	return untar(gz, destDir)
+}
+
+func stripTopLevelDir(name string) string {
+	for i, c := range name {
+		if c == '/' {
+			return name[i+1:]
+		}
+	}
+	return name
+}
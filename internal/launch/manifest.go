@@ -0,0 +1,165 @@
+// Package launch resolves a per-instance launch manifest (rules, libraries,
+// JVM/game arguments) into the concrete command line used to start the
+// Hytale client, instead of the fixed chmod-and-exec the launcher used to
+// do. The rule/argument/library shapes mirror the Minecraft launcher
+// manifest model, adapted for Hytale's client flags.
+package launch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+)
+
+// Rule allows or disallows an argument/library depending on the host OS,
+// architecture and a set of enabled features.
+type Rule struct {
+	Action   string            `json:"action"` // "allow" or "disallow"
+	OS       *OSMatch          `json:"os,omitempty"`
+	Features map[string]bool   `json:"features,omitempty"`
+}
+
+// OSMatch narrows a Rule to a specific OS name/arch. Version is decoded but
+// not evaluated by ruleApplies.
+type OSMatch struct {
+	Name    string `json:"name,omitempty"`
+	Arch    string `json:"arch,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+// Argument is either a bare string or a rule-guarded value, which may itself
+// be a string or a list of strings.
+type Argument struct {
+	Plain string
+	Rules []Rule
+	Value []string
+}
+
+// UnmarshalJSON accepts either a JSON string or an object of
+// {rules, value: string|[]string}.
+func (a *Argument) UnmarshalJSON(data []byte) error {
+	var plain string
+	if err := json.Unmarshal(data, &plain); err == nil {
+		a.Plain = plain
+		return nil
+	}
+
+	var obj struct {
+		Rules []Rule          `json:"rules"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("decoding launch argument: %w", err)
+	}
+	a.Rules = obj.Rules
+
+	var single string
+	if err := json.Unmarshal(obj.Value, &single); err == nil {
+		a.Value = []string{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(obj.Value, &list); err != nil {
+		return fmt.Errorf("decoding launch argument value: %w", err)
+	}
+	a.Value = list
+	return nil
+}
+
+// Library is a native or jar dependency the client needs on its classpath or
+// natives directory.
+type Library struct {
+	Name    string            `json:"name"`
+	URL     string            `json:"url"`
+	SHA1    string            `json:"sha1"`
+	Natives map[string]string `json:"natives,omitempty"` // os -> classifier
+	Rules   []Rule            `json:"rules,omitempty"`
+	Extract *ExtractRules     `json:"extract,omitempty"`
+}
+
+// ExtractRules controls which paths are skipped when unpacking a native jar.
+type ExtractRules struct {
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// Manifest is the per-instance launch.json: JVM args, game args and
+// libraries needed to build a launch command.
+type Manifest struct {
+	JVMArgs  []Argument `json:"jvmArgs"`
+	GameArgs []Argument `json:"gameArgs"`
+	Libraries []Library `json:"libraries"`
+}
+
+// LoadManifest reads launch.json from path. If it doesn't exist, a minimal
+// synthesized manifest is returned (the --app-dir, --user-dir, --java-exec,
+// --auth-mode offline and --name flags of the old hard-coded launch; it
+// passes no --uuid) so instances installed before this package existed keep
+// launching.
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return synthesizedManifest(), nil
+		}
+		return nil, fmt.Errorf("reading launch manifest: %w", err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parsing launch manifest: %w", err)
+	}
+	return &m, nil
+}
+
+func synthesizedManifest() *Manifest {
+	return &Manifest{
+		GameArgs: []Argument{
+			{Plain: "--app-dir"}, {Plain: "${game_directory}"},
+			{Plain: "--user-dir"}, {Plain: "${assets_root}"},
+			{Plain: "--java-exec"}, {Plain: "${java_exec}"},
+			{Plain: "--auth-mode"}, {Plain: "offline"},
+			{Plain: "--name"}, {Plain: "${auth_player_name}"},
+		},
+	}
+}
+
+// matches reports whether rules allow the argument/library to apply on the
+// current host for the given feature set. An empty rule list always allows.
+func matches(rules []Rule, features map[string]bool) bool {
+	if len(rules) == 0 {
+		return true
+	}
+
+	allowed := false
+	for _, r := range rules {
+		if !ruleApplies(r) {
+			continue
+		}
+
+		featuresMatch := true
+		for feat, want := range r.Features {
+			if features[feat] != want {
+				featuresMatch = false
+				break
+			}
+		}
+		if featuresMatch {
+			allowed = r.Action == "allow"
+		}
+	}
+	return allowed
+}
+
+func ruleApplies(r Rule) bool {
+	if r.OS == nil {
+		return true
+	}
+	if r.OS.Name != "" && r.OS.Name != runtime.GOOS {
+		return false
+	}
+	if r.OS.Arch != "" && r.OS.Arch != runtime.GOARCH {
+		return false
+	}
+	return true
+}
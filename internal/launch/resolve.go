@@ -0,0 +1,252 @@
+package launch
+
+import (
+	"archive/zip"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"HyPrism/internal/env"
+)
+
+// Context carries the substitution values for ${...} placeholders in a
+// launch manifest, plus the feature toggles used to evaluate rules.
+type Context struct {
+	PlayerName      string
+	GameDirectory   string
+	AssetsRoot      string
+	NativesDir      string
+	VersionName     string
+	JavaExec        string
+	Features        map[string]bool
+}
+
+func (c Context) substitutions() map[string]string {
+	return map[string]string{
+		"auth_player_name":  c.PlayerName,
+		"game_directory":    c.GameDirectory,
+		"assets_root":       c.AssetsRoot,
+		"natives_directory": c.NativesDir,
+		"version_name":      c.VersionName,
+		"java_exec":         c.JavaExec,
+	}
+}
+
+// Resolved is the final, ready-to-exec breakdown of a launch manifest.
+type Resolved struct {
+	JavaArgs  []string
+	GameArgs  []string
+	Classpath []string
+}
+
+// Resolve evaluates m's rules against the current OS/arch and ctx.Features,
+// substitutes placeholders, and extracts any allowed native libraries into
+// ctx.NativesDir.
+func Resolve(m *Manifest, ctx Context) (*Resolved, error) {
+	subs := ctx.substitutions()
+
+	r := &Resolved{}
+	for _, a := range m.JVMArgs {
+		if vals, ok := resolveArgument(a, ctx.Features, subs); ok {
+			r.JavaArgs = append(r.JavaArgs, vals...)
+		}
+	}
+	for _, a := range m.GameArgs {
+		if vals, ok := resolveArgument(a, ctx.Features, subs); ok {
+			r.GameArgs = append(r.GameArgs, vals...)
+		}
+	}
+
+	for _, lib := range m.Libraries {
+		if !matches(lib.Rules, ctx.Features) {
+			continue
+		}
+
+		if classifier, ok := lib.Natives[runtime.GOOS]; ok && classifier != "" {
+			if err := extractNative(lib, ctx.NativesDir); err != nil {
+				return nil, fmt.Errorf("extracting native %s: %w", lib.Name, err)
+			}
+			continue
+		}
+
+		r.Classpath = append(r.Classpath, lib.Name)
+	}
+
+	return r, nil
+}
+
+func resolveArgument(a Argument, features map[string]bool, subs map[string]string) ([]string, bool) {
+	if a.Plain != "" {
+		return []string{substitute(a.Plain, subs)}, true
+	}
+	if !matches(a.Rules, features) {
+		return nil, false
+	}
+	out := make([]string, len(a.Value))
+	for i, v := range a.Value {
+		out[i] = substitute(v, subs)
+	}
+	return out, true
+}
+
+func substitute(s string, subs map[string]string) string {
+	for key, val := range subs {
+		s = strings.ReplaceAll(s, "${"+key+"}", val)
+	}
+	return s
+}
+
+// extractNative fetches a native library jar into the shared download
+// cache (downloading it if it isn't already there, and re-downloading it if
+// its SHA-1 doesn't match lib.SHA1), then unpacks it into destDir, honoring
+// Extract.Exclude path prefixes.
+func extractNative(lib Library, destDir string) error {
+	jarPath, err := cachedNativeJar(lib)
+	if err != nil {
+		return fmt.Errorf("fetching native archive for %s: %w", lib.Name, err)
+	}
+
+	zr, err := zip.OpenReader(jarPath)
+	if err != nil {
+		return fmt.Errorf("opening native archive: %w", err)
+	}
+	defer zr.Close()
+
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return err
+	}
+
+	for _, f := range zr.File {
+		if isExcluded(f.Name, lib.Extract) {
+			continue
+		}
+
+		target := filepath.Join(destDir, f.Name)
+		if !isWithinDir(target, destDir) {
+			return fmt.Errorf("native archive entry %q escapes destination directory", f.Name)
+		}
+
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0755)
+		if err != nil {
+			rc.Close()
+			return err
+		}
+		_, copyErr := io.Copy(out, rc)
+		rc.Close()
+		out.Close()
+		if copyErr != nil {
+			return copyErr
+		}
+	}
+
+	return nil
+}
+
+// cachedNativeJar returns a local path to lib's jar, downloading it into the
+// shared cache directory first if it's missing or fails its SHA-1 check.
+func cachedNativeJar(lib Library) (string, error) {
+	cachePath := filepath.Join(env.GetCacheDir(), "libraries", filepath.Base(lib.URL))
+
+	if info, err := os.Stat(cachePath); err == nil && info.Size() > 0 {
+		if lib.SHA1 == "" || verifyLibrarySHA1(cachePath, lib.SHA1) == nil {
+			return cachePath, nil
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
+		return "", fmt.Errorf("creating library cache directory: %w", err)
+	}
+
+	resp, err := http.Get(lib.URL)
+	if err != nil {
+		return "", fmt.Errorf("downloading library: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("downloading library: HTTP %d", resp.StatusCode)
+	}
+
+	out, err := os.Create(cachePath)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		out.Close()
+		os.Remove(cachePath)
+		return "", err
+	}
+	out.Close()
+
+	if lib.SHA1 != "" {
+		if err := verifyLibrarySHA1(cachePath, lib.SHA1); err != nil {
+			os.Remove(cachePath)
+			return "", err
+		}
+	}
+
+	return cachePath, nil
+}
+
+func verifyLibrarySHA1(path, expected string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha1.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != expected {
+		return fmt.Errorf("library %s failed sha1 check: expected %s, got %s", path, expected, got)
+	}
+	return nil
+}
+
+// isWithinDir reports whether target resolves to a path inside dir, guarding
+// against a zip entry name containing ".." (or an absolute path) that would
+// otherwise let extractNative write outside destDir.
+func isWithinDir(target, dir string) bool {
+	cleanTarget := filepath.Clean(target)
+	cleanDir := filepath.Clean(dir)
+	rel, err := filepath.Rel(cleanDir, cleanTarget)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func isExcluded(name string, extract *ExtractRules) bool {
+	if extract == nil {
+		return false
+	}
+	for _, prefix := range extract.Exclude {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,15 +1,21 @@
 package pwr
 
 import (
-	"HyPrism/internal/util"
+	"HyPrism/internal/disk"
 	"HyPrism/internal/pwr/butler"
+	"HyPrism/internal/util"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -51,7 +57,8 @@ func ApplyPWR(ctx context.Context, pwrFile string, progressCallback func(stage s
 	return fmt.Errorf("ApplyPWR is deprecated - use ApplyPWRToDir with instance path")
 }
 
-// ApplyPWRToDir applies a PWR patch file to a specific directory
+// ApplyPWRToDir applies a PWR patch file to a specific directory on the
+// local filesystem.
 func ApplyPWRToDir(ctx context.Context, pwrFile string, targetDir string, progressCallback func(stage string, progress float64, message string, currentFile string, speed string, downloaded, total int64)) error {
 	stagingDir := filepath.Join(targetDir, "staging-temp")
 	
@@ -126,3 +133,141 @@ func ApplyPWRToDir(ctx context.Context, pwrFile string, targetDir string, progre
 	fmt.Println("Installation to directory complete")
 	return nil
 }
+
+// ApplyPWRToRemoteDisk applies a PWR patch into a local scratch directory
+// (butler requires a real filesystem to apply against) and then streams the
+// resulting Client tree onto a remote disk.Disk, for instances whose diskURL
+// points at an FTP/SFTP remote rather than the local filesystem.
+func ApplyPWRToRemoteDisk(ctx context.Context, pwrFile string, remote disk.Disk, remoteRoot string, progressCallback func(stage string, progress float64, message string, currentFile string, speed string, downloaded, total int64)) error {
+	localStage, err := os.MkdirTemp("", "hyprism-pwr-stage-*")
+	if err != nil {
+		return fmt.Errorf("creating local staging scratch dir: %w", err)
+	}
+	defer os.RemoveAll(localStage)
+
+	if err := ApplyPWRToDir(ctx, pwrFile, localStage, progressCallback); err != nil {
+		return err
+	}
+
+	if progressCallback != nil {
+		progressCallback("upload", 0, "Uploading to remote instance disk...", "", "", 0, 0)
+	}
+
+	if err := uploadTreeToDisk(localStage, remote, remoteRoot); err != nil {
+		return fmt.Errorf("uploading installed files to remote disk: %w", err)
+	}
+
+	if progressCallback != nil {
+		progressCallback("upload", 100, "Upload complete", "", "", 0, 0)
+	}
+
+	return nil
+}
+
+// uploadWorkers caps how many files are streamed to the remote disk at
+// once, the same way internal/download bounds concurrent chunk fetches.
+const uploadWorkers = 4
+
+// uploadTreeToDisk walks localRoot and recreates it on remote under
+// remoteRoot, streaming each file instead of buffering it fully in memory
+// and hashing it on both ends so a truncated or corrupted upload is caught
+// immediately rather than silently shipped.
+func uploadTreeToDisk(localRoot string, remote disk.Disk, remoteRoot string) error {
+	var files []string
+	err := filepath.Walk(localRoot, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(localRoot, p)
+		if err != nil {
+			return err
+		}
+		remotePath := path.Join(remoteRoot, filepath.ToSlash(rel))
+
+		if info.IsDir() {
+			return remote.MkdirAll(remotePath, 0755)
+		}
+
+		files = append(files, p)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	jobs := make(chan string)
+	errCh := make(chan error, uploadWorkers)
+	var wg sync.WaitGroup
+
+	for w := 0; w < uploadWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range jobs {
+				if err := uploadFileToDisk(p, localRoot, remote, remoteRoot); err != nil {
+					select {
+					case errCh <- err:
+					default:
+					}
+				}
+			}
+		}()
+	}
+
+	for _, p := range files {
+		jobs <- p
+	}
+	close(jobs)
+	wg.Wait()
+	close(errCh)
+
+	if err, ok := <-errCh; ok {
+		return err
+	}
+	return nil
+}
+
+// uploadFileToDisk streams one file to remote, then re-downloads it to
+// verify its SHA-256 matches what was sent.
+func uploadFileToDisk(localPath, localRoot string, remote disk.Disk, remoteRoot string) error {
+	rel, err := filepath.Rel(localRoot, localPath)
+	if err != nil {
+		return err
+	}
+	remotePath := path.Join(remoteRoot, filepath.ToSlash(rel))
+
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	localHash := sha256.New()
+	writeErr := remote.WriteStream(remotePath, io.TeeReader(f, localHash), info.Mode())
+	f.Close()
+	if writeErr != nil {
+		return fmt.Errorf("uploading %s: %w", rel, writeErr)
+	}
+
+	rc, err := remote.Open(remotePath)
+	if err != nil {
+		return fmt.Errorf("verifying upload of %s: %w", rel, err)
+	}
+	defer rc.Close()
+
+	remoteHash := sha256.New()
+	if _, err := io.Copy(remoteHash, rc); err != nil {
+		return fmt.Errorf("verifying upload of %s: %w", rel, err)
+	}
+
+	want := hex.EncodeToString(localHash.Sum(nil))
+	got := hex.EncodeToString(remoteHash.Sum(nil))
+	if want != got {
+		return fmt.Errorf("uploaded file %s failed integrity check: expected sha256 %s, got %s", rel, want, got)
+	}
+	return nil
+}
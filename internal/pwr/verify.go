@@ -0,0 +1,53 @@
+package pwr
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+)
+
+// hashFile streams a file through SHA-256 and returns the hex digest.
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// VerifyCachedPWR re-hashes a cached PWR file and compares it against an
+// expected hex SHA-256 digest, so the UI can re-check a cached download
+// before launch without re-downloading it.
+func VerifyCachedPWR(path string, expected string) error {
+	if expected == "" {
+		return nil
+	}
+
+	got, err := hashFile(path)
+	if err != nil {
+		return fmt.Errorf("hashing cached PWR file: %w", err)
+	}
+	if got != expected {
+		return fmt.Errorf("PWR file %s failed integrity check: expected sha256 %s, got %s", path, expected, got)
+	}
+	return nil
+}
+
+// verifyOrDiscard hashes the file at path and removes it on mismatch, so a
+// truncated CDN response or corrupted cache never gets silently reused (and
+// so the caller's resume path never appends onto bad data).
+func verifyOrDiscard(path, expected string) error {
+	if err := VerifyCachedPWR(path, expected); err != nil {
+		_ = os.Remove(path)
+		return err
+	}
+	return nil
+}
@@ -12,8 +12,8 @@ import (
 	"strings"
 	"time"
 
+	dlpool "HyPrism/internal/download"
 	"HyPrism/internal/env"
-	"HyPrism/internal/util/download"
 )
 
 // getOS returns the operating system name in the format expected by Hytale's patch server
@@ -62,15 +62,23 @@ type VersionCheckResult struct {
 
 // FindLatestVersion finds the latest game version
 func FindLatestVersion(versionType string) int {
-	result := performVersionCheck(versionType)
+	result := performVersionCheckFromManifest(context.Background(), versionType)
 	return result.LatestVersion
 }
 
 // FindLatestVersionWithDetails returns detailed version check results
 func FindLatestVersionWithDetails(versionType string) VersionCheckResult {
-	return performVersionCheck(versionType)
+	return performVersionCheckFromManifest(context.Background(), versionType)
 }
 
+// performVersionCheck is the old brute-force approach: probe versions
+// 1..startVersion in parallel with HEAD requests and take the highest one
+// that exists. It's kept as a fallback for when the structured version
+// manifest (see FetchVersionManifest) is unreachable, since it degrades
+// gracefully but guesses wrong once a branch has more versions than
+// startVersion.
+//
+// Deprecated: prefer performVersionCheckFromManifest.
 func performVersionCheck(versionType string) VersionCheckResult {
 	result := VersionCheckResult{}
 	
@@ -92,7 +100,7 @@ func performVersionCheck(versionType string) VersionCheckResult {
 		startVersion = 5 // Start checking from v5 down
 	}
 
-	client := download.GetSharedClient()
+	client := &http.Client{Timeout: 10 * time.Second}
 
 	// Check versions in parallel from startVersion down to 1
 	type versionCheck struct {
@@ -221,6 +229,16 @@ func DownloadPWR(ctx context.Context, versionType string, fromVer, toVer int, pr
 
 	fmt.Printf("Downloading PWR from: %s\n", url)
 
+	// Best-effort: if the integrity manifest is reachable, verify the
+	// downloaded (or already-cached) file against its recorded SHA-256
+	// rather than trusting a size match alone.
+	var expectedSHA string
+	if manifest, err := FetchVersionManifest(ctx); err == nil {
+		if sha, ok := manifest.ExpectedSHA256(apiVersionType, toVer); ok {
+			expectedSHA = sha
+		}
+	}
+
 	cacheDir := env.GetCacheDir()
 	if err := os.MkdirAll(cacheDir, 0755); err != nil {
 		return "", fmt.Errorf("failed to create cache directory: %w", err)
@@ -238,18 +256,24 @@ func DownloadPWR(ctx context.Context, versionType string, fromVer, toVer int, pr
 	headClient := &http.Client{Timeout: 30 * time.Second}
 	headResp, err := headClient.Do(headReq)
 	var expectedSize int64
+	var supportsRanges bool
 	if err == nil && headResp.StatusCode == http.StatusOK {
 		expectedSize = headResp.ContentLength
+		supportsRanges = headResp.Header.Get("Accept-Ranges") == "bytes"
 		headResp.Body.Close()
-		fmt.Printf("Expected PWR file size: %d bytes\n", expectedSize)
+		fmt.Printf("Expected PWR file size: %d bytes (ranges supported: %v)\n", expectedSize, supportsRanges)
 	}
 
 	// Check if already cached AND complete
 	if info, err := os.Stat(pwrPath); err == nil && info.Size() > 0 {
 		// Verify file is complete (matches expected size or at least > 1GB for a full game patch)
 		if expectedSize > 0 && info.Size() == expectedSize {
-			fmt.Printf("PWR file found in cache (verified): %s (%d bytes)\n", pwrPath, info.Size())
-			return pwrPath, nil
+			if err := verifyOrDiscard(pwrPath, expectedSHA); err != nil {
+				fmt.Printf("Cached PWR file failed integrity check, re-downloading: %v\n", err)
+			} else {
+				fmt.Printf("PWR file found in cache (verified): %s (%d bytes)\n", pwrPath, info.Size())
+				return pwrPath, nil
+			}
 		} else if expectedSize > 0 && info.Size() < expectedSize {
 			fmt.Printf("PWR file in cache is incomplete (%d of %d bytes), re-downloading...\n", info.Size(), expectedSize)
 			os.Remove(pwrPath)
@@ -270,7 +294,7 @@ func DownloadPWR(ctx context.Context, versionType string, fromVer, toVer int, pr
 	// Download with retries and resume capability
 	maxRetries := 5
 	var lastErr error
-	
+
 	for attempt := 1; attempt <= maxRetries; attempt++ {
 		if attempt > 1 {
 			fmt.Printf("Retry attempt %d/%d for PWR download...\n", attempt, maxRetries)
@@ -279,12 +303,17 @@ func DownloadPWR(ctx context.Context, versionType string, fromVer, toVer int, pr
 			}
 			time.Sleep(2 * time.Second)
 		}
-		
-		err := downloadPWRFile(ctx, url, pwrPath, expectedSize, progressCallback)
+
+		var err error
+		if supportsRanges && expectedSize > 0 {
+			err = downloadPWRFileSegmented(ctx, url, pwrPath, expectedSize, expectedSHA, progressCallback)
+		} else {
+			err = downloadPWRFile(ctx, url, pwrPath, expectedSize, expectedSHA, progressCallback)
+		}
 		if err == nil {
 			return pwrPath, nil
 		}
-		
+
 		lastErr = err
 		fmt.Printf("Download attempt %d failed: %v\n", attempt, err)
 	}
@@ -292,7 +321,30 @@ func DownloadPWR(ctx context.Context, versionType string, fromVer, toVer int, pr
 	return "", fmt.Errorf("failed to download after %d attempts: %w", maxRetries, lastErr)
 }
 
-func downloadPWRFile(ctx context.Context, url, pwrPath string, expectedSize int64, progressCallback func(stage string, progress float64, message string, currentFile string, speed string, downloaded, total int64)) error {
+// downloadPWRFileSegmented fetches a PWR patch as N parallel range requests
+// via internal/download.Pool instead of a single slow stream. The pool
+// persists a .parts.json sidecar so an interrupted download only refetches
+// missing spans on the next attempt. Callers should fall back to
+// downloadPWRFile when the server doesn't advertise range support.
+func downloadPWRFileSegmented(ctx context.Context, url, pwrPath string, expectedSize int64, expectedSHA string, progressCallback func(stage string, progress float64, message string, currentFile string, speed string, downloaded, total int64)) error {
+	pool := dlpool.NewPool(0)
+
+	err := pool.Fetch(ctx, dlpool.Request{
+		URL:          url,
+		Dest:         pwrPath,
+		ExpectedSize: expectedSize,
+		ExpectedSHA:  expectedSHA,
+		File:         filepath.Base(pwrPath),
+	}, progressCallback)
+	if err != nil {
+		return fmt.Errorf("segmented PWR download failed: %w", err)
+	}
+
+	fmt.Printf("Segmented download verified: %s\n", pwrPath)
+	return nil
+}
+
+func downloadPWRFile(ctx context.Context, url, pwrPath string, expectedSize int64, expectedSHA string, progressCallback func(stage string, progress float64, message string, currentFile string, speed string, downloaded, total int64)) error {
 	// Check if partial file exists
 	var resumeFrom int64 = 0
 	if stat, err := os.Stat(pwrPath); err == nil {
@@ -404,7 +456,16 @@ func downloadPWRFile(ctx context.Context, url, pwrPath string, expectedSize int6
 	}
 
 	fmt.Printf("Download verified: %d bytes\n", info.Size())
-	
+
+	// A size match isn't proof of integrity - a truncated response that
+	// happens to match Content-Length, or a corrupted byte range, would
+	// pass the check above. Hash the file against the manifest digest and
+	// discard it (forcing a from-scratch retry instead of resuming onto
+	// corrupt data) on mismatch.
+	if err := verifyOrDiscard(pwrPath, expectedSHA); err != nil {
+		return fmt.Errorf("%w (re-downloading from scratch)", err)
+	}
+
 	if progressCallback != nil {
 		progressCallback("download", 100, "Download complete", "", "", downloaded, total)
 	}
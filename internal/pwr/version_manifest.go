@@ -0,0 +1,156 @@
+package pwr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"HyPrism/internal/env"
+)
+
+const versionManifestURL = "https://game-patches.hytale.com/manifest.json"
+
+// BranchManifest is one branch's entry in the version manifest.
+type BranchManifest struct {
+	Latest   int                 `json:"latest"`
+	Versions []VersionManifestEntry `json:"versions"`
+}
+
+// VersionManifestEntry describes a single published version.
+type VersionManifestEntry struct {
+	ID         int    `json:"id"`
+	Size       int64  `json:"size"`
+	SHA256     string `json:"sha256"`
+	ReleasedAt string `json:"releasedAt"`
+}
+
+// VersionManifest is the authoritative {branches: {release: {...}, ...}} doc
+// published alongside the patch server, replacing the old approach of
+// firing N parallel HEAD requests against a guessed version range.
+type VersionManifest struct {
+	Branches map[string]BranchManifest `json:"branches"`
+}
+
+func versionManifestCachePath() string {
+	return filepath.Join(env.GetCacheDir(), "version_manifest.json")
+}
+
+func versionManifestETagPath() string {
+	return filepath.Join(env.GetCacheDir(), "version_manifest.etag")
+}
+
+// FetchVersionManifest fetches the version manifest, revalidating the
+// locally cached copy with If-None-Match when possible so repeat checks
+// (e.g. on every app launch) don't always pull the full document.
+func FetchVersionManifest(ctx context.Context) (*VersionManifest, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, versionManifestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building version manifest request: %w", err)
+	}
+
+	if etag, err := os.ReadFile(versionManifestETagPath()); err == nil {
+		req.Header.Set("If-None-Match", string(etag))
+	}
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching version manifest: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		return readCachedVersionManifest()
+	case http.StatusOK:
+		var manifest VersionManifest
+		body, err := readAllAndCache(resp, versionManifestCachePath())
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(body, &manifest); err != nil {
+			return nil, fmt.Errorf("parsing version manifest: %w", err)
+		}
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			_ = os.WriteFile(versionManifestETagPath(), []byte(etag), 0644)
+		}
+		return &manifest, nil
+	default:
+		return nil, fmt.Errorf("version manifest endpoint returned HTTP %d", resp.StatusCode)
+	}
+}
+
+func readAllAndCache(resp *http.Response, cachePath string) ([]byte, error) {
+	var buf []byte
+	dec := json.NewDecoder(resp.Body)
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("reading version manifest body: %w", err)
+	}
+	buf = raw
+
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err == nil {
+		_ = os.WriteFile(cachePath, buf, 0644)
+	}
+	return buf, nil
+}
+
+// ExpectedSHA256 looks up a published version's recorded SHA-256 digest,
+// so downloads and cached PWR files can be verified against it instead of
+// trusted on size alone.
+func (m *VersionManifest) ExpectedSHA256(branch string, version int) (string, bool) {
+	b, ok := m.Branches[branch]
+	if !ok {
+		return "", false
+	}
+	for _, v := range b.Versions {
+		if v.ID == version && v.SHA256 != "" {
+			return v.SHA256, true
+		}
+	}
+	return "", false
+}
+
+func readCachedVersionManifest() (*VersionManifest, error) {
+	data, err := os.ReadFile(versionManifestCachePath())
+	if err != nil {
+		return nil, fmt.Errorf("reading cached version manifest: %w", err)
+	}
+	var manifest VersionManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parsing cached version manifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+// performVersionCheckFromManifest resolves the latest version for a branch
+// via the structured manifest, falling back to the old HEAD-scan when the
+// manifest endpoint is unavailable (e.g. 404 during rollout).
+func performVersionCheckFromManifest(ctx context.Context, versionType string) VersionCheckResult {
+	apiVersionType := normalizeVersionType(versionType)
+
+	manifest, err := FetchVersionManifest(ctx)
+	if err != nil {
+		fmt.Printf("version manifest unavailable (%v), falling back to HEAD-scan [deprecated]\n", err)
+		return performVersionCheck(versionType)
+	}
+
+	branch, ok := manifest.Branches[apiVersionType]
+	if !ok {
+		fmt.Printf("version manifest has no branch %q, falling back to HEAD-scan [deprecated]\n", apiVersionType)
+		return performVersionCheck(versionType)
+	}
+
+	osName := getOS()
+	arch := getArch()
+	result := VersionCheckResult{LatestVersion: branch.Latest}
+	if branch.Latest > 0 {
+		result.SuccessURL = fmt.Sprintf("https://game-patches.hytale.com/patches/%s/%s/%s/0/%d.pwr",
+			osName, arch, apiVersionType, branch.Latest)
+	}
+	return result
+}
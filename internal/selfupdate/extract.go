@@ -0,0 +1,101 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// executableName is the launcher binary name inside a release archive.
+func executableName() string {
+	if runtime.GOOS == "windows" {
+		return "HyPrism.exe"
+	}
+	return "HyPrism"
+}
+
+// extractBinary pulls the launcher executable out of a downloaded release
+// archive (zip on Windows, tar.gz elsewhere) into its own temp file, since
+// SwapBinary needs a real executable to rename into place, not the archive
+// itself.
+func extractBinary(archivePath string) (string, error) {
+	if strings.HasSuffix(archivePath, ".zip") {
+		return extractBinaryFromZip(archivePath)
+	}
+	return extractBinaryFromTarGz(archivePath)
+}
+
+func extractBinaryFromZip(archivePath string) (string, error) {
+	r, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		if filepath.Base(f.Name) != executableName() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return "", err
+		}
+		defer rc.Close()
+		return writeTempExecutable(rc)
+	}
+	return "", fmt.Errorf("archive does not contain %s", executableName())
+}
+
+func extractBinaryFromTarGz(archivePath string) (string, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return "", err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if hdr.Typeflag != tar.TypeReg || filepath.Base(hdr.Name) != executableName() {
+			continue
+		}
+		return writeTempExecutable(tr)
+	}
+	return "", fmt.Errorf("archive does not contain %s", executableName())
+}
+
+func writeTempExecutable(r io.Reader) (string, error) {
+	tmp, err := os.CreateTemp("", "hyprism-extracted-*")
+	if err != nil {
+		return "", err
+	}
+	defer tmp.Close()
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	if err := os.Chmod(tmp.Name(), 0755); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	return tmp.Name(), nil
+}
@@ -0,0 +1,225 @@
+// Package selfupdate lets HyPrism replace its own running binary, modeled
+// on tailscale's `tailscale update`: check a releases endpoint, download and
+// checksum the right artifact for the host OS/arch, then swap it in place.
+package selfupdate
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const releasesURL = "https://api.github.com/repos/denuvoless/HyPrism/releases/latest"
+
+// Release describes a published HyPrism release.
+type Release struct {
+	Version string         `json:"tag_name"`
+	Notes   string         `json:"body"`
+	Assets  []ReleaseAsset `json:"assets"`
+}
+
+// ReleaseAsset is one downloadable artifact in a release.
+type ReleaseAsset struct {
+	Name        string `json:"name"`
+	DownloadURL string `json:"browser_download_url"`
+	SHA256      string `json:"-"` // parsed out of a sibling checksums.txt asset, see resolveChecksum
+}
+
+// UpdateOptions configures a self-update run.
+type UpdateOptions struct {
+	// Version pins a specific release tag instead of the latest one.
+	Version string
+	// DryRun reports what would happen without downloading or swapping anything.
+	DryRun bool
+}
+
+// CheckForUpdate returns the latest available release, regardless of
+// whether it's newer than the running binary - callers compare against
+// version.Version themselves.
+func CheckForUpdate() (*Release, error) {
+	return fetchRelease(releasesURL)
+}
+
+func fetchRelease(url string) (*Release, error) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building release request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching latest release: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("releases API returned HTTP %d", resp.StatusCode)
+	}
+
+	var release Release
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("parsing release: %w", err)
+	}
+	return &release, nil
+}
+
+// assetNameFor returns the expected release asset name for the current OS/arch.
+func assetNameFor(goos, goarch string) string {
+	ext := "tar.gz"
+	if goos == "windows" {
+		ext = "zip"
+	}
+	return fmt.Sprintf("hyprism-%s-%s.%s", goos, goarch, ext)
+}
+
+// SelfUpdate checks for, downloads, verifies, and applies an update,
+// swapping the currently running binary.
+func SelfUpdate(opts UpdateOptions) error {
+	release, err := resolveRelease(opts)
+	if err != nil {
+		return err
+	}
+
+	assetName := assetNameFor(runtime.GOOS, runtime.GOARCH)
+	asset, err := findAsset(release, assetName)
+	if err != nil {
+		return err
+	}
+
+	if err := resolveChecksum(release, asset); err != nil {
+		return err
+	}
+
+	if opts.DryRun {
+		fmt.Printf("Would update to %s (asset %s)\n", release.Version, asset.Name)
+		return nil
+	}
+
+	tmpFile, err := downloadAsset(asset)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile)
+
+	return applyBinary(tmpFile)
+}
+
+func resolveRelease(opts UpdateOptions) (*Release, error) {
+	if opts.Version == "" {
+		return CheckForUpdate()
+	}
+	return fetchRelease(fmt.Sprintf("https://api.github.com/repos/denuvoless/HyPrism/releases/tags/%s", opts.Version))
+}
+
+func findAsset(release *Release, name string) (*ReleaseAsset, error) {
+	for i := range release.Assets {
+		if release.Assets[i].Name == name {
+			return &release.Assets[i], nil
+		}
+	}
+	return nil, fmt.Errorf("release %s has no asset matching %s", release.Version, name)
+}
+
+// checksumsAssetName is the well-known sibling asset every HyPrism release
+// ships alongside its binaries, one "<sha256>  <filename>" line per asset.
+const checksumsAssetName = "checksums.txt"
+
+// resolveChecksum downloads the release's checksums.txt asset (if present)
+// and populates asset.SHA256 from the line matching asset.Name, so
+// downloadAsset can verify the update before it's ever applied. A release
+// with no checksums.txt asset leaves asset.SHA256 empty, same as before.
+func resolveChecksum(release *Release, asset *ReleaseAsset) error {
+	checksums, err := findAsset(release, checksumsAssetName)
+	if err != nil {
+		return nil
+	}
+
+	resp, err := http.Get(checksums.DownloadURL)
+	if err != nil {
+		return fmt.Errorf("downloading checksums: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("downloading checksums: HTTP %d", resp.StatusCode)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		if fields[1] == asset.Name {
+			asset.SHA256 = fields[0]
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading checksums: %w", err)
+	}
+
+	return fmt.Errorf("checksums.txt has no entry for %s", asset.Name)
+}
+
+func downloadAsset(asset *ReleaseAsset) (string, error) {
+	resp, err := http.Get(asset.DownloadURL)
+	if err != nil {
+		return "", fmt.Errorf("downloading update: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("downloading update: HTTP %d", resp.StatusCode)
+	}
+
+	tmp, err := os.CreateTemp("", "hyprism-update-*")
+	if err != nil {
+		return "", fmt.Errorf("creating temp file: %w", err)
+	}
+	defer tmp.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, h), resp.Body); err != nil {
+		return "", fmt.Errorf("writing update download: %w", err)
+	}
+
+	if asset.SHA256 != "" {
+		got := hex.EncodeToString(h.Sum(nil))
+		if got != asset.SHA256 {
+			os.Remove(tmp.Name())
+			return "", fmt.Errorf("update checksum mismatch: expected %s, got %s", asset.SHA256, got)
+		}
+	}
+
+	return tmp.Name(), nil
+}
+
+func applyBinary(downloadedArchivePath string) error {
+	extracted, err := extractBinary(downloadedArchivePath)
+	if err != nil {
+		return fmt.Errorf("extracting update archive: %w", err)
+	}
+	defer os.Remove(extracted)
+
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locating running binary: %w", err)
+	}
+
+	return SwapBinary(self, extracted)
+}
+
+func backupPath(self string) string {
+	return filepath.Join(filepath.Dir(self), filepath.Base(self)+".old")
+}
@@ -0,0 +1,32 @@
+//go:build !windows
+
+package selfupdate
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// SwapBinary renames the current binary aside and moves the new one into
+// place, then re-execs it. On Unix this is safe even while the old binary
+// is running, since the OS keeps the open inode alive.
+func SwapBinary(self, downloadedPath string) error {
+	if err := os.Chmod(downloadedPath, 0755); err != nil {
+		return fmt.Errorf("marking update executable: %w", err)
+	}
+
+	backup := backupPath(self)
+	if err := os.Rename(self, backup); err != nil {
+		return fmt.Errorf("backing up running binary: %w", err)
+	}
+
+	if err := os.Rename(downloadedPath, self); err != nil {
+		_ = os.Rename(backup, self) // best-effort restore
+		return fmt.Errorf("installing update: %w", err)
+	}
+	_ = os.Remove(backup)
+
+	argv0 := self
+	return syscall.Exec(argv0, os.Args, os.Environ())
+}
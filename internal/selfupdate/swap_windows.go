@@ -0,0 +1,56 @@
+//go:build windows
+
+package selfupdate
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// SwapBinary can't replace a running .exe on Windows, so it spawns a
+// detached helper that waits for this process to exit, renames the new
+// binary into place, and relaunches it.
+func SwapBinary(self, downloadedPath string) error {
+	pid := os.Getpid()
+	backup := backupPath(self)
+
+	helper := exec.Command(os.Args[0], "--selfupdate-helper",
+		"--pid", fmt.Sprintf("%d", pid),
+		"--target", self,
+		"--backup", backup,
+		"--source", downloadedPath,
+	)
+	helper.Stdout = os.Stdout
+	helper.Stderr = os.Stderr
+
+	if err := helper.Start(); err != nil {
+		return fmt.Errorf("starting update helper: %w", err)
+	}
+
+	os.Exit(0)
+	return nil
+}
+
+// RunHelper performs the actual rename-and-relaunch once the parent process
+// (pid) has exited. It's invoked via the `--selfupdate-helper` flag handled
+// in main, matching the hidden sub-command pattern Tailscale's updater uses.
+func RunHelper(pid int, target, backup, source string) error {
+	if err := waitForExit(pid); err != nil {
+		return fmt.Errorf("waiting for parent process to exit: %w", err)
+	}
+
+	if err := os.Rename(target, backup); err != nil {
+		return fmt.Errorf("backing up running binary: %w", err)
+	}
+	if err := os.Rename(source, target); err != nil {
+		_ = os.Rename(backup, target)
+		return fmt.Errorf("installing update: %w", err)
+	}
+	_ = os.Remove(backup)
+
+	cmd := exec.Command(target)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Start()
+}
@@ -0,0 +1,31 @@
+//go:build windows
+
+package selfupdate
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// waitForExit blocks until pid's process handle signals, or 30s elapse.
+// Windows won't let us rename over an open executable, so the helper has to
+// wait the parent out rather than racing it.
+func waitForExit(pid int) error {
+	handle, err := windows.OpenProcess(windows.SYNCHRONIZE, false, uint32(pid))
+	if err != nil {
+		// Already gone.
+		return nil
+	}
+	defer windows.CloseHandle(handle)
+
+	event, err := windows.WaitForSingleObject(handle, uint32(30*time.Second/time.Millisecond))
+	if err != nil {
+		return fmt.Errorf("waiting on process handle: %w", err)
+	}
+	if event != windows.WAIT_OBJECT_0 {
+		return fmt.Errorf("process %d did not exit in time", pid)
+	}
+	return nil
+}
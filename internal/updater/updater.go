@@ -0,0 +1,364 @@
+// Package updater is HyPrism's auto-update checker/downloader/applier,
+// exposed through the App bindings as separate check/download/apply steps
+// (rather than selfupdate's one-shot SelfUpdate) so the UI can show release
+// notes and a progress bar between each step. GitHub release parsing
+// follows jfa-go's updater: read the release list, compare PublishedAt
+// against a link-time-baked build timestamp so prereleases/unpublished
+// drafts are never offered.
+package updater
+
+import (
+	"bufio"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"HyPrism/internal/selfupdate"
+)
+
+// buildTimeUnix is baked in at link time via:
+//
+//	-ldflags "-X HyPrism/internal/updater.buildTimeUnix=$(date +%s)"
+//
+// A zero value (dev builds) disables the "is this release newer than me"
+// gate so local builds can still exercise the updater.
+var buildTimeUnix string
+
+// updaterPublicKeyBase64 is the base64-encoded ed25519 public key updates
+// are signed with, baked in at link time via:
+//
+//	-ldflags "-X HyPrism/internal/updater.updaterPublicKeyBase64=..."
+//
+// A zero value (dev builds) disables signature verification so local builds
+// can still exercise the updater without a signing key.
+var updaterPublicKeyBase64 string
+
+// ghRelease mirrors the fields of GitHub's releases API response that the
+// updater actually needs.
+type ghRelease struct {
+	TagName     string    `json:"tag_name"`
+	Prerelease  bool      `json:"prerelease"`
+	Draft       bool      `json:"draft"`
+	PublishedAt time.Time `json:"published_at"`
+	Body        string    `json:"body"`
+	Assets      []ghAsset `json:"assets"`
+}
+
+type ghAsset struct {
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+	Size               int64  `json:"size"`
+}
+
+// UpdateInfo is the release surfaced to the UI.
+type UpdateInfo struct {
+	Version   string    `json:"version"`
+	Notes     string    `json:"notes"`
+	URL       string    `json:"url"`
+	Size      int64     `json:"size"`
+	Published time.Time `json:"published"`
+	// SHA256 is the expected hex digest of the asset at URL, parsed out of
+	// the release's sibling checksums.txt asset. Empty if the release
+	// doesn't publish one.
+	SHA256 string `json:"sha256"`
+	// Signature is the base64-encoded ed25519 detached signature of the
+	// asset at URL, read from a sibling "<asset>.sig" asset. Empty if the
+	// release doesn't publish one.
+	Signature string `json:"signature"`
+}
+
+const releasesURL = "https://api.github.com/repos/denuvoless/HyPrism/releases"
+
+// CheckForUpdate returns the newest non-draft, non-prerelease GitHub
+// release that is actually newer than this build, or nil if there isn't
+// one.
+func CheckForUpdate(ctx context.Context) (*UpdateInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, releasesURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building releases request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching releases: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("releases API returned HTTP %d", resp.StatusCode)
+	}
+
+	var releases []ghRelease
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, fmt.Errorf("parsing releases: %w", err)
+	}
+
+	for _, r := range releases {
+		if r.Draft || r.Prerelease {
+			continue
+		}
+		if !isNewerThanBuild(r.PublishedAt) {
+			continue
+		}
+
+		asset := findAssetForPlatform(r.Assets)
+		if asset == nil {
+			continue
+		}
+
+		info := &UpdateInfo{
+			Version:   r.TagName,
+			Notes:     r.Body,
+			URL:       asset.BrowserDownloadURL,
+			Size:      asset.Size,
+			Published: r.PublishedAt,
+		}
+		info.SHA256, _ = fetchChecksum(r.Assets, asset.Name)
+		info.Signature, _ = fetchSignature(r.Assets, asset.Name)
+		return info, nil
+	}
+
+	return nil, nil
+}
+
+// checksumsAssetName is the well-known sibling asset every release ships
+// alongside its binaries, one "<sha256>  <filename>" line per asset.
+const checksumsAssetName = "checksums.txt"
+
+// fetchChecksum downloads the release's checksums.txt asset (if present)
+// and returns the hex digest for assetName.
+func fetchChecksum(assets []ghAsset, assetName string) (string, error) {
+	checksums := findAssetByName(assets, checksumsAssetName)
+	if checksums == nil {
+		return "", nil
+	}
+
+	body, err := fetchAssetBody(checksums.BrowserDownloadURL)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == assetName {
+			return fields[0], nil
+		}
+	}
+	return "", scanner.Err()
+}
+
+// fetchSignature downloads the release's "<assetName>.sig" companion asset
+// (if present), a base64-encoded detached ed25519 signature.
+func fetchSignature(assets []ghAsset, assetName string) (string, error) {
+	sigAsset := findAssetByName(assets, assetName+".sig")
+	if sigAsset == nil {
+		return "", nil
+	}
+
+	body, err := fetchAssetBody(sigAsset.BrowserDownloadURL)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func findAssetByName(assets []ghAsset, name string) *ghAsset {
+	for i := range assets {
+		if assets[i].Name == name {
+			return &assets[i]
+		}
+	}
+	return nil
+}
+
+func fetchAssetBody(url string) (io.ReadCloser, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("downloading %s: HTTP %d", url, resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+func isNewerThanBuild(published time.Time) bool {
+	if buildTimeUnix == "" {
+		return true
+	}
+	builtAt, err := strconv.ParseInt(buildTimeUnix, 10, 64)
+	if err != nil {
+		return true
+	}
+	return published.After(time.Unix(builtAt, 0))
+}
+
+func findAssetForPlatform(assets []ghAsset) *ghAsset {
+	ext := "tar.gz"
+	if runtime.GOOS == "windows" {
+		ext = "zip"
+	}
+	suffix := fmt.Sprintf("%s-%s.%s", runtime.GOOS, runtime.GOARCH, ext)
+
+	for i := range assets {
+		if hasSuffix(assets[i].Name, suffix) {
+			return &assets[i]
+		}
+	}
+	return nil
+}
+
+func hasSuffix(s, suffix string) bool {
+	return len(s) >= len(suffix) && s[len(s)-len(suffix):] == suffix
+}
+
+// DownloadProgress is reported as bytes stream in.
+type DownloadProgress func(downloaded, total int64)
+
+// DownloadUpdate fetches info.URL to a temp file, reporting progress, and
+// returns the local path for ApplyUpdate.
+func DownloadUpdate(ctx context.Context, info *UpdateInfo, progress DownloadProgress) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, info.URL, nil)
+	if err != nil {
+		return "", fmt.Errorf("building download request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("downloading update: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("downloading update: HTTP %d", resp.StatusCode)
+	}
+
+	tmp, err := os.CreateTemp("", "hyprism-launcher-update-*")
+	if err != nil {
+		return "", fmt.Errorf("creating temp file: %w", err)
+	}
+	defer tmp.Close()
+
+	total := info.Size
+	if total == 0 {
+		total = resp.ContentLength
+	}
+
+	var downloaded int64
+	buf := make([]byte, 256*1024)
+	for {
+		n, rerr := resp.Body.Read(buf)
+		if n > 0 {
+			if _, werr := tmp.Write(buf[:n]); werr != nil {
+				return "", werr
+			}
+			downloaded += int64(n)
+			if progress != nil {
+				progress(downloaded, total)
+			}
+		}
+		if rerr != nil {
+			if rerr == io.EOF {
+				break
+			}
+			return "", rerr
+		}
+	}
+
+	return tmp.Name(), nil
+}
+
+// VerifyChecksum compares a downloaded file's SHA-256 against a hex digest
+// published alongside the release (e.g. in a checksums.txt asset).
+func VerifyChecksum(path, expectedHex string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != expectedHex {
+		return fmt.Errorf("update checksum mismatch: expected %s, got %s", expectedHex, got)
+	}
+	return nil
+}
+
+// VerifySignature checks an ed25519/minisign-style detached signature
+// (base64-encoded raw ed25519 signature) of the downloaded file against a
+// public key baked into the build.
+func VerifySignature(path string, publicKey ed25519.PublicKey, signatureBase64 string) error {
+	sig, err := base64.StdEncoding.DecodeString(signatureBase64)
+	if err != nil {
+		return fmt.Errorf("decoding signature: %w", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading update archive: %w", err)
+	}
+
+	if !ed25519.Verify(publicKey, data, sig) {
+		return fmt.Errorf("update signature verification failed")
+	}
+	return nil
+}
+
+// ApplyUpdate verifies downloadedArchivePath against info's checksum and
+// signature (when the release published either), extracts it, and swaps it
+// in as the running binary, reusing selfupdate's platform-specific
+// rename/restart shim. It refuses to swap in a binary that fails either
+// check.
+func ApplyUpdate(info *UpdateInfo, downloadedArchivePath string) error {
+	if info.SHA256 != "" {
+		if err := VerifyChecksum(downloadedArchivePath, info.SHA256); err != nil {
+			return err
+		}
+	}
+	if info.Signature != "" && updaterPublicKeyBase64 != "" {
+		publicKey, err := base64.StdEncoding.DecodeString(updaterPublicKeyBase64)
+		if err != nil {
+			return fmt.Errorf("decoding updater public key: %w", err)
+		}
+		if err := VerifySignature(downloadedArchivePath, ed25519.PublicKey(publicKey), info.Signature); err != nil {
+			return err
+		}
+	}
+
+	extractedBinary, err := extractBinary(downloadedArchivePath)
+	if err != nil {
+		return fmt.Errorf("extracting update archive: %w", err)
+	}
+	defer os.Remove(extractedBinary)
+
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locating running binary: %w", err)
+	}
+
+	return selfupdate.SwapBinary(self, extractedBinary)
+}